@@ -109,6 +109,14 @@ func loadTableACL(id string) (SqlPermissions, error) {
 	return ta, nil
 }
 
+// grantRow is a row of SHOW GRANT results
+type grantRow struct {
+	Principal  string `json:"Principal"`
+	ActionType string `json:"ActionType"`
+	ObjectType string `json:"ObjectType"`
+	ObjectKey  string `json:"ObjectKey"`
+}
+
 func (ta *SqlPermissions) read() error {
 	thisType, thisKey := ta.typeAndKey()
 	if thisType == "" && thisKey == "" {
@@ -128,8 +136,10 @@ func (ta *SqlPermissions) read() error {
 	ta.PrivilegeAssignments = []PrivilegeAssignment{}
 
 	// iterate over existing permissions over given data object
-	var currentPrincipal, currentAction, currentType, currentKey string
-	for currentGrantsOnThis.Scan(&currentPrincipal, &currentAction, &currentType, &currentKey) {
+	var grant grantRow
+	for currentGrantsOnThis.Scan(&grant) {
+		currentPrincipal, currentAction := grant.Principal, grant.ActionType
+		currentType, currentKey := grant.ObjectType, grant.ObjectKey
 		if currentType == "CATALOG$" {
 			currentType = "CATALOG"
 			currentKey = ""
@@ -170,6 +180,9 @@ func (ta *SqlPermissions) read() error {
 		// add action for the principal on current iteration
 		*privileges = append(*privileges, currentAction)
 	}
+	if err := currentGrantsOnThis.Err(); err != nil {
+		return fmt.Errorf("cannot read current grants: %w", err)
+	}
 	return nil
 }
 
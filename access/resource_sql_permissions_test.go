@@ -89,6 +89,44 @@ func TestTableACLGrants(t *testing.T) {
 	assert.Len(t, ta.PrivilegeAssignments[0].Privileges, 2)
 }
 
+func TestTableACLGrants_Schema(t *testing.T) {
+	column := func(name string) map[string]interface{} {
+		return map[string]interface{}{"name": name, "type": `"string"`, "metadata": "{}"}
+	}
+	ta := SqlPermissions{Table: "foo", exec: mockCommand(func(string) common.CommandResults {
+		return common.CommandResults{
+			ResultType: "table",
+			Schema: []interface{}{column("ObjectType"), column("ObjectKey"),
+				column("Principal"), column("ActionType")},
+			Data: []interface{}{
+				[]interface{}{"TABLE", "`default`.`foo`", "users", "SELECT"},
+				[]interface{}{"TABLE", "`default`.`foo`", "users", "OWN"},
+				[]interface{}{"DATABASE", "default", "users", "SELECT"},
+			},
+		}
+	})}
+	err := ta.read()
+	require.NoError(t, err)
+	assert.Equal(t, []PrivilegeAssignment{{"users", []string{"SELECT"}}}, ta.PrivilegeAssignments)
+}
+
+func TestTableACLGrants_ScanError(t *testing.T) {
+	ta := SqlPermissions{Table: "foo", exec: mockCommand(func(string) common.CommandResults {
+		return common.CommandResults{
+			ResultType: "table",
+			Data:       []interface{}{[]interface{}{"users", "SELECT"}},
+		}
+	})}
+	err := ta.read()
+	assert.EqualError(t, err, "cannot read current grants: expected 4 columns, but got 2")
+}
+
+type mockCommand common.CommandMock
+
+func (mc mockCommand) Execute(clusterID, language, commandStr string) common.CommandResults {
+	return mc(commandStr)
+}
+
 type failedCommand string
 
 func (fc failedCommand) Execute(clusterID, language, commandStr string) common.CommandResults {
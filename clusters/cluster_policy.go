@@ -0,0 +1,143 @@
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/policies"
+)
+
+// Values of virtual `cluster_type` policy attribute
+const (
+	ClusterTypeAllPurpose = "all-purpose"
+	ClusterTypeJob        = "job"
+)
+
+// PolicyAttributes returns cluster attributes in the notation of policy definitions
+func (cluster Cluster) PolicyAttributes(clusterType string) (map[string]interface{}, error) {
+	attributes, err := policies.FlattenPolicyAttributes(cluster)
+	if err != nil {
+		return nil, err
+	}
+	attributes["cluster_type"] = clusterType
+	return attributes, nil
+}
+
+// ValidatePolicyCompliance checks cluster definition against its policy during plan,
+// so that violations are reported per attribute instead of failing during apply.
+// Top-level attributes, for which isKnown returns false, are skipped. Only violations
+// fail the plan, and policies, that cannot be read or parsed, or cannot be fetched, because
+// workspace is not yet known, are skipped with a warning.
+func ValidatePolicyCompliance(ctx context.Context, c *common.DatabricksClient,
+	cluster Cluster, clusterType string, isKnown func(attr string) bool) error {
+	if cluster.PolicyID == "" || !isKnown("policy_id") {
+		return nil
+	}
+	// host may come from a profile or environment, that are resolved during authentication
+	if err := c.Authenticate(ctx); err != nil {
+		log.Printf("[WARN] cannot validate cluster policy %s: %s", cluster.PolicyID, err)
+		return nil
+	}
+	if c.Host == "" {
+		log.Printf("[WARN] cannot validate cluster policy %s, because host is not known yet", cluster.PolicyID)
+		return nil
+	}
+	policy, err := policies.NewClusterPoliciesAPI(ctx, c).Get(cluster.PolicyID)
+	if err != nil {
+		// users, that can use the policy, are not always allowed to read it,
+		// so the check is skipped and the backend validates the cluster on apply
+		log.Printf("[WARN] cannot validate cluster policy %s: %s", cluster.PolicyID, err)
+		return nil
+	}
+	definition, err := policies.ParsePolicyDefinition(policy.Definition)
+	if err != nil {
+		log.Printf("[WARN] cannot validate cluster policy %s: %s", cluster.PolicyID, err)
+		return nil
+	}
+	attributes, err := cluster.PolicyAttributes(clusterType)
+	if err != nil {
+		return err
+	}
+	violations := definition.Validate(attributes, func(path string) bool {
+		attr := strings.SplitN(path, ".", 2)[0]
+		switch attr {
+		case "cluster_type":
+			return true
+		case "num_workers":
+			// cluster size is either fixed or autoscaling,
+			// so policy elements for the other one don't apply
+			return cluster.Autoscale == nil && isKnown(attr)
+		case "autoscale":
+			return cluster.Autoscale != nil && isKnown(attr)
+		}
+		return isKnown(attr)
+	})
+	if len(violations) == 0 {
+		return nil
+	}
+	problems := []string{}
+	for _, v := range violations {
+		problems = append(problems, v.String())
+	}
+	return fmt.Errorf("cluster does not comply with policy %s: %s",
+		cluster.PolicyID, strings.Join(problems, "; "))
+}
+
+// policyDefaults returns policy definition for clusters with `apply_policy_default_values`,
+// skipping elements of cluster size mode, that is not used by the cluster
+func (a ClustersAPI) policyDefaults(cluster Cluster) (policies.PolicyDefinition, error) {
+	if !cluster.ApplyPolicyDefaultValues || cluster.PolicyID == "" {
+		return nil, nil
+	}
+	policy, err := policies.NewClusterPoliciesAPI(a.context, a.client).Get(cluster.PolicyID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get cluster policy %s: %w", cluster.PolicyID, err)
+	}
+	definition, err := policies.ParsePolicyDefinition(policy.Definition)
+	if err != nil {
+		return nil, fmt.Errorf("cluster policy %s: %w", cluster.PolicyID, err)
+	}
+	for path := range definition {
+		if cluster.Autoscale == nil && strings.HasPrefix(path, "autoscale.") {
+			delete(definition, path)
+		}
+		if cluster.Autoscale != nil && path == "num_workers" {
+			delete(definition, path)
+		}
+	}
+	return definition, nil
+}
+
+// ApplyPolicyDefaultValues merges fixed and default values of cluster policy
+// into attributes, that are not specified in the cluster definition
+func (a ClustersAPI) ApplyPolicyDefaultValues(cluster *Cluster) error {
+	definition, err := a.policyDefaults(*cluster)
+	if err != nil || definition == nil {
+		return err
+	}
+	return definition.ApplyDefaults(cluster)
+}
+
+// RemovePolicyPresets removes values, that were set by the policy of configured cluster and are not
+// in its configuration, from actual, which is a pointer to the cluster returned from API. Values of
+// attributes, for which keep returns true, are not removed.
+func (a ClustersAPI) RemovePolicyPresets(configured Cluster, actual interface{}, keep func(path string) bool) error {
+	definition, err := a.policyDefaults(configured)
+	if err != nil || definition == nil {
+		return err
+	}
+	attributes, err := policies.FlattenPolicyAttributes(configured)
+	if err != nil {
+		return err
+	}
+	return definition.RemovePresets(actual, func(path string) bool {
+		if keep(path) {
+			return true
+		}
+		_, ok := attributes[path]
+		return ok
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -42,6 +43,17 @@ const (
 	AzureAvailabilitySpotWithFallback = "SPOT_WITH_FALLBACK_AZURE"
 )
 
+// https://docs.gcp.databricks.com/dev-tools/api/latest/instance-pools.html#instancepoolgcpattributes
+const (
+	// GcpAvailabilityPreemptible is preemptible instance type for instance pools
+	GcpAvailabilityPreemptible = "PREEMPTIBLE_GCP"
+	// GcpAvailabilityOnDemand is OnDemand instance type for instance pools
+	GcpAvailabilityOnDemand = "ON_DEMAND_GCP"
+	// GcpAvailabilityPreemptibleWithFallback is preemptible instance type with option
+	// to fallback into on-demand if instance cannot be acquired
+	GcpAvailabilityPreemptibleWithFallback = "PREEMPTIBLE_WITH_FALLBACK_GCP"
+)
+
 // AzureDiskVolumeType is disk type on azure vms
 type AzureDiskVolumeType string
 
@@ -336,15 +348,16 @@ type Cluster struct {
 	EnableElasticDisk         bool       `json:"enable_elastic_disk,omitempty" tf:"computed"`
 	EnableLocalDiskEncryption bool       `json:"enable_local_disk_encryption,omitempty" tf:"computed"`
 
-	NodeTypeID             string           `json:"node_type_id,omitempty" tf:"group:node_type,computed"`
-	DriverNodeTypeID       string           `json:"driver_node_type_id,omitempty" tf:"group:node_type,computed"`
-	InstancePoolID         string           `json:"instance_pool_id,omitempty" tf:"group:node_type"`
-	DriverInstancePoolID   string           `json:"driver_instance_pool_id,omitempty" tf:"group:node_type,computed"`
-	PolicyID               string           `json:"policy_id,omitempty"`
-	AwsAttributes          *AwsAttributes   `json:"aws_attributes,omitempty" tf:"conflicts:instance_pool_id,suppress_diff"`
-	AzureAttributes        *AzureAttributes `json:"azure_attributes,omitempty" tf:"conflicts:instance_pool_id,suppress_diff"`
-	GcpAttributes          *GcpAttributes   `json:"gcp_attributes,omitempty" tf:"conflicts:instance_pool_id,suppress_diff"`
-	AutoterminationMinutes int32            `json:"autotermination_minutes,omitempty"`
+	NodeTypeID               string           `json:"node_type_id,omitempty" tf:"group:node_type,computed"`
+	DriverNodeTypeID         string           `json:"driver_node_type_id,omitempty" tf:"group:node_type,computed"`
+	InstancePoolID           string           `json:"instance_pool_id,omitempty" tf:"group:node_type"`
+	DriverInstancePoolID     string           `json:"driver_instance_pool_id,omitempty" tf:"group:node_type,computed"`
+	PolicyID                 string           `json:"policy_id,omitempty"`
+	ApplyPolicyDefaultValues bool             `json:"apply_policy_default_values,omitempty"`
+	AwsAttributes            *AwsAttributes   `json:"aws_attributes,omitempty" tf:"conflicts:instance_pool_id,suppress_diff"`
+	AzureAttributes          *AzureAttributes `json:"azure_attributes,omitempty" tf:"conflicts:instance_pool_id,suppress_diff"`
+	GcpAttributes            *GcpAttributes   `json:"gcp_attributes,omitempty" tf:"conflicts:instance_pool_id,suppress_diff"`
+	AutoterminationMinutes   int32            `json:"autotermination_minutes,omitempty"`
 
 	SparkConf    map[string]string `json:"spark_conf,omitempty"`
 	SparkEnvVars map[string]string `json:"spark_env_vars,omitempty"`
@@ -631,6 +644,7 @@ func (a ClustersAPI) waitForClusterStatus(clusterID string, desired ClusterState
 					clusterInfo.TerminationReason.Code, clusterInfo.TerminationReason.Type,
 					clusterInfo.TerminationReason.Parameters)
 			}
+			details += a.recentEventsSummary(clusterID)
 			return resource.NonRetryableError(fmt.Errorf(
 				"%s is not able to transition from %s to %s: %s%s. Please see %s for more details",
 				clusterID, clusterInfo.State, desired, clusterInfo.StateMessage, details, docLink))
@@ -641,6 +655,16 @@ func (a ClustersAPI) waitForClusterStatus(clusterID string, desired ClusterState
 	})
 }
 
+// Restart restarts a running Spark cluster given its ID and waits till it's running again
+func (a ClustersAPI) Restart(clusterID string) error {
+	err := a.client.Post(a.context, "/clusters/restart", ClusterID{ClusterID: clusterID}, nil)
+	if err != nil {
+		return err
+	}
+	_, err = a.waitForClusterStatus(clusterID, ClusterStateRunning)
+	return err
+}
+
 // Terminate terminates a Spark cluster given its ID
 func (a ClustersAPI) Terminate(clusterID string) error {
 	err := a.client.Post(a.context, "/clusters/delete", ClusterID{ClusterID: clusterID}, nil)
@@ -729,6 +753,55 @@ func (a ClustersAPI) Events(eventsRequest EventsRequest) ([]ClusterEvent, error)
 	return events[0:curPos], err
 }
 
+// String returns human-readable summary of the event, used in error messages
+func (event ClusterEvent) String() string {
+	ts := time.Unix(0, event.Timestamp*int64(time.Millisecond)).UTC().Format(time.RFC3339)
+	summary := fmt.Sprintf("%s %s", ts, event.Type)
+	if event.Details.User != "" {
+		summary += fmt.Sprintf(" by %s", event.Details.User)
+	}
+	if reason := event.Details.Reason; reason != nil {
+		summary += fmt.Sprintf(" (%s", reason.Code)
+		if reason.Type != "" {
+			summary += fmt.Sprintf(", %s", reason.Type)
+		}
+		keys := []string{}
+		for k := range reason.Parameters {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			summary += fmt.Sprintf(", %s: %s", k, reason.Parameters[k])
+		}
+		summary += ")"
+	}
+	return summary
+}
+
+// recentEventsSummary returns the latest events of a cluster, so that failures
+// explain why cluster couldn't reach the desired state. Errors are only logged,
+// because this is used to enrich other errors.
+func (a ClustersAPI) recentEventsSummary(clusterID string) string {
+	events, err := a.Events(EventsRequest{
+		ClusterID: clusterID,
+		Order:     SortDescending,
+		Limit:     5,
+		MaxItems:  5,
+	})
+	if err != nil {
+		log.Printf("[WARN] Cannot get events of cluster %s: %s", clusterID, err)
+		return ""
+	}
+	summaries := []string{}
+	for _, event := range events {
+		summaries = append(summaries, event.String())
+	}
+	if len(summaries) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(". Last events: %s", strings.Join(summaries, "; "))
+}
+
 // List return information about all pinned clusters, currently active clusters,
 // up to 70 of the most recently terminated interactive clusters in the past 30 days,
 // and up to 30 of the most recently terminated job clusters in the past 30 days
@@ -229,6 +229,42 @@ func TestWaitForClusterStatus_NotReachable(t *testing.T) {
 					Parameters: map[string]string{"abc": "def"}},
 			},
 		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/clusters/events",
+			ExpectedRequest: EventsRequest{
+				ClusterID: "abc",
+				Order:     SortDescending,
+				Limit:     5,
+			},
+			Response: EventsResponse{
+				Events: []ClusterEvent{
+					{
+						ClusterID: "abc",
+						Timestamp: 1609459200000,
+						Type:      EvTypeTerminating,
+						Details: EventDetails{
+							Reason: &TerminationReason{
+								Code: "CLOUD_PROVIDER_LAUNCH_FAILURE",
+								Type: "CLOUD_FAILURE",
+								Parameters: map[string]string{
+									"databricks_error_message": "quota exceeded",
+								},
+							},
+						},
+					},
+					{
+						ClusterID: "abc",
+						Timestamp: 1609459100000,
+						Type:      EvTypeCreating,
+						Details: EventDetails{
+							User: "me@example.com",
+						},
+					},
+				},
+				TotalCount: 2,
+			},
+		},
 	})
 	defer server.Close()
 	require.NoError(t, err)
@@ -240,6 +276,9 @@ func TestWaitForClusterStatus_NotReachable(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "abc is not able to transition from UNKNOWN to RUNNING: Something strange is going on")
 	assert.Contains(t, err.Error(), "code: unknown, type: broken")
+	assert.Contains(t, err.Error(), "Last events: 2021-01-01T00:00:00Z TERMINATING "+
+		"(CLOUD_PROVIDER_LAUNCH_FAILURE, CLOUD_FAILURE, databricks_error_message: quota exceeded); "+
+		"2020-12-31T23:58:20Z CREATING by me@example.com")
 }
 
 func TestWaitForClusterStatus_NormalRetry(t *testing.T) {
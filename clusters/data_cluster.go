@@ -0,0 +1,126 @@
+package clusters
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// clusterInfo mirrors ClusterInfo for the data source schema, as float32
+// cluster_cores of ClusterInfo has no Terraform schema type
+type clusterInfo struct {
+	NumWorkers                int32              `json:"num_workers,omitempty"`
+	AutoScale                 *AutoScale         `json:"autoscale,omitempty"`
+	ClusterID                 string             `json:"cluster_id,omitempty"`
+	CreatorUserName           string             `json:"creator_user_name,omitempty"`
+	Driver                    *SparkNode         `json:"driver,omitempty"`
+	Executors                 []SparkNode        `json:"executors,omitempty"`
+	SparkContextID            int64              `json:"spark_context_id,omitempty"`
+	JdbcPort                  int32              `json:"jdbc_port,omitempty"`
+	ClusterName               string             `json:"cluster_name,omitempty"`
+	SparkVersion              string             `json:"spark_version"`
+	SparkConf                 map[string]string  `json:"spark_conf,omitempty"`
+	AwsAttributes             *AwsAttributes     `json:"aws_attributes,omitempty"`
+	AzureAttributes           *AzureAttributes   `json:"azure_attributes,omitempty"`
+	GcpAttributes             *GcpAttributes     `json:"gcp_attributes,omitempty"`
+	NodeTypeID                string             `json:"node_type_id,omitempty"`
+	DriverNodeTypeID          string             `json:"driver_node_type_id,omitempty"`
+	SSHPublicKeys             []string           `json:"ssh_public_keys,omitempty"`
+	CustomTags                map[string]string  `json:"custom_tags,omitempty"`
+	ClusterLogConf            *StorageInfo       `json:"cluster_log_conf,omitempty"`
+	InitScripts               []StorageInfo      `json:"init_scripts,omitempty"`
+	SparkEnvVars              map[string]string  `json:"spark_env_vars,omitempty"`
+	AutoterminationMinutes    int32              `json:"autotermination_minutes,omitempty"`
+	EnableElasticDisk         bool               `json:"enable_elastic_disk,omitempty"`
+	EnableLocalDiskEncryption bool               `json:"enable_local_disk_encryption,omitempty"`
+	InstancePoolID            string             `json:"instance_pool_id,omitempty"`
+	DriverInstancePoolID      string             `json:"driver_instance_pool_id,omitempty" tf:"computed"`
+	PolicyID                  string             `json:"policy_id,omitempty"`
+	SingleUserName            string             `json:"single_user_name,omitempty"`
+	ClusterSource             Availability       `json:"cluster_source,omitempty"`
+	DockerImage               *DockerImage       `json:"docker_image,omitempty"`
+	State                     ClusterState       `json:"state"`
+	StateMessage              string             `json:"state_message,omitempty"`
+	StartTime                 int64              `json:"start_time,omitempty"`
+	TerminateTime             int64              `json:"terminate_time,omitempty"`
+	LastStateLossTime         int64              `json:"last_state_loss_time,omitempty"`
+	LastActivityTime          int64              `json:"last_activity_time,omitempty"`
+	ClusterMemoryMb           int64              `json:"cluster_memory_mb,omitempty"`
+	ClusterCores              float64            `json:"cluster_cores,omitempty"`
+	DefaultTags               map[string]string  `json:"default_tags"`
+	ClusterLogStatus          *LogSyncStatus     `json:"cluster_log_status,omitempty"`
+	TerminationReason         *TerminationReason `json:"termination_reason,omitempty"`
+	DataSecurityMode          string             `json:"data_security_mode,omitempty"`
+}
+
+// DataSourceCluster returns information about cluster specified by ID or exact name
+func DataSourceCluster() *schema.Resource {
+	type clusterData struct {
+		ClusterID   string       `json:"cluster_id,omitempty" tf:"computed"`
+		ClusterName string       `json:"cluster_name,omitempty" tf:"computed"`
+		ClusterInfo *clusterInfo `json:"cluster_info,omitempty" tf:"computed"`
+	}
+	s := common.StructToSchema(clusterData{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		common.MarkComputed(s["cluster_info"].Elem.(*schema.Resource).Schema)
+		s["cluster_id"].ExactlyOneOf = []string{"cluster_id", "cluster_name"}
+		s["cluster_name"].ExactlyOneOf = []string{"cluster_id", "cluster_name"}
+		return s
+	})
+	return &schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			var data clusterData
+			err := common.DataToStructPointer(d, s, &data)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			clustersAPI := NewClustersAPI(ctx, m)
+			if data.ClusterName != "" {
+				data.ClusterID, err = clustersAPI.findClusterIDByName(data.ClusterName)
+				if err != nil {
+					return diag.FromErr(err)
+				}
+			}
+			// response is read into the data source struct, as its fields have the same JSON names
+			var info clusterInfo
+			err = wrapMissingClusterError(clustersAPI.client.Get(ctx, "/clusters/get",
+				ClusterID{ClusterID: data.ClusterID}, &info), data.ClusterID)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			data.ClusterInfo = &info
+			data.ClusterName = info.ClusterName
+			err = common.StructToData(data, s, d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId(info.ClusterID)
+			return nil
+		},
+	}
+}
+
+// findClusterIDByName returns ID of the only cluster with exactly the same name
+func (a ClustersAPI) findClusterIDByName(name string) (string, error) {
+	clusters, err := a.List()
+	if err != nil {
+		return "", err
+	}
+	ids := []string{}
+	for _, cl := range clusters {
+		if cl.ClusterName == name {
+			ids = append(ids, cl.ClusterID)
+		}
+	}
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("there is no cluster with name '%s'", name)
+	case 1:
+		return ids[0], nil
+	}
+	return "", fmt.Errorf("there are %d clusters with name '%s': %v", len(ids), name, ids)
+}
@@ -0,0 +1,103 @@
+package clusters
+
+import (
+	"context"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// maximum page size of events API
+const maxEventsPageSize = 500
+
+// clusterEventData is flattened representation of ClusterEvent
+type clusterEventData struct {
+	Timestamp         int64             `json:"timestamp,omitempty"`
+	Type              ClusterEventType  `json:"type,omitempty"`
+	User              string            `json:"user,omitempty"`
+	CurrentNumWorkers int32             `json:"current_num_workers,omitempty"`
+	TargetNumWorkers  int32             `json:"target_num_workers,omitempty"`
+	Cause             string            `json:"cause,omitempty"`
+	ReasonCode        string            `json:"reason_code,omitempty"`
+	ReasonType        string            `json:"reason_type,omitempty"`
+	ReasonParameters  map[string]string `json:"reason_parameters,omitempty"`
+}
+
+func newClusterEventData(event ClusterEvent) clusterEventData {
+	data := clusterEventData{
+		Timestamp:         event.Timestamp,
+		Type:              event.Type,
+		User:              event.Details.User,
+		CurrentNumWorkers: event.Details.CurrentNumWorkers,
+		TargetNumWorkers:  event.Details.TargetNumWorkers,
+	}
+	if event.Details.ResizeCause != nil {
+		data.Cause = string(*event.Details.ResizeCause)
+	}
+	if event.Details.Reason != nil {
+		data.ReasonCode = event.Details.Reason.Code
+		data.ReasonType = event.Details.Reason.Type
+		data.ReasonParameters = event.Details.Reason.Parameters
+	}
+	return data
+}
+
+// DataSourceClusterEvents returns filtered events of a cluster
+func DataSourceClusterEvents() *schema.Resource {
+	type clusterEventsData struct {
+		ClusterID  string             `json:"cluster_id"`
+		EventTypes []ClusterEventType `json:"event_types,omitempty" tf:"slice_set"`
+		StartTime  int64              `json:"start_time,omitempty"`
+		EndTime    int64              `json:"end_time,omitempty"`
+		Order      SortOrder          `json:"order,omitempty"`
+		Limit      int64              `json:"limit,omitempty"`
+		Events     []clusterEventData `json:"events,omitempty" tf:"computed"`
+	}
+	s := common.StructToSchema(clusterEventsData{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		common.MarkComputed(s["events"].Elem.(*schema.Resource).Schema)
+		s["order"].ValidateFunc = validation.StringInSlice([]string{
+			string(SortAscending), string(SortDescending)}, false)
+		s["limit"].ValidateFunc = validation.IntAtLeast(1)
+		s["start_time"].ValidateFunc = validation.IntAtLeast(0)
+		s["end_time"].ValidateFunc = validation.IntAtLeast(0)
+		return s
+	})
+	return &schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			var data clusterEventsData
+			err := common.DataToStructPointer(d, s, &data)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			request := EventsRequest{
+				ClusterID:  data.ClusterID,
+				EventTypes: data.EventTypes,
+				StartTime:  data.StartTime,
+				EndTime:    data.EndTime,
+				Order:      data.Order,
+				MaxItems:   uint(data.Limit),
+			}
+			if data.Limit > 0 && data.Limit < maxEventsPageSize {
+				request.Limit = data.Limit
+			}
+			events, err := NewClustersAPI(ctx, m).Events(request)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			data.Events = []clusterEventData{}
+			for _, event := range events {
+				data.Events = append(data.Events, newClusterEventData(event))
+			}
+			err = common.StructToData(data, s, d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId(data.ClusterID)
+			return nil
+		},
+	}
+}
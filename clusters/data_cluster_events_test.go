@@ -0,0 +1,102 @@
+package clusters
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataSourceClusterEvents(t *testing.T) {
+	cause := ResizeCause("AUTOSCALE")
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				ExpectedRequest: EventsRequest{
+					ClusterID:  "abc",
+					EventTypes: []ClusterEventType{EvTypeTerminating},
+					StartTime:  1609459200000,
+					Order:      SortDescending,
+					Limit:      2,
+				},
+				Response: EventsResponse{
+					Events: []ClusterEvent{
+						{
+							ClusterID: "abc",
+							Timestamp: 1609459300000,
+							Type:      EvTypeTerminating,
+							Details: EventDetails{
+								Reason: &TerminationReason{
+									Code: "INACTIVITY",
+									Type: "SUCCESS",
+									Parameters: map[string]string{
+										"inactivity_duration_min": "60",
+									},
+								},
+							},
+						},
+						{
+							ClusterID: "abc",
+							Timestamp: 1609459250000,
+							Type:      EvTypeTerminating,
+							Details: EventDetails{
+								User:              "me@example.com",
+								CurrentNumWorkers: 2,
+								TargetNumWorkers:  4,
+								ResizeCause:       &cause,
+							},
+						},
+					},
+					TotalCount: 10,
+				},
+			},
+		},
+		Resource:    DataSourceClusterEvents(),
+		NonWritable: true,
+		Read:        true,
+		New:         true,
+		ID:          "_",
+		HCL: `
+		cluster_id = "abc"
+		event_types = ["TERMINATING"]
+		start_time = 1609459200000
+		order = "DESC"
+		limit = 2`,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, 2, d.Get("events.#"))
+	assert.Equal(t, "INACTIVITY", d.Get("events.0.reason_code"))
+	assert.Equal(t, "SUCCESS", d.Get("events.0.reason_type"))
+	assert.Equal(t, "60", d.Get("events.0.reason_parameters.inactivity_duration_min"))
+	assert.Equal(t, 1609459250000, d.Get("events.1.timestamp"))
+	assert.Equal(t, "me@example.com", d.Get("events.1.user"))
+	assert.Equal(t, 4, d.Get("events.1.target_num_workers"))
+	assert.Equal(t, "AUTOSCALE", d.Get("events.1.cause"))
+}
+
+func TestDataSourceClusterEvents_Error(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				Response: common.APIErrorBody{
+					ErrorCode: "INVALID_REQUEST",
+					Message:   "Internal error happened",
+				},
+				Status: 400,
+			},
+		},
+		Resource:    DataSourceClusterEvents(),
+		NonWritable: true,
+		Read:        true,
+		New:         true,
+		ID:          "_",
+		HCL:         `cluster_id = "abc"`,
+	}.ExpectError(t, "Internal error happened")
+}
@@ -0,0 +1,148 @@
+package clusters
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testClusterInfo = ClusterInfo{
+	ClusterID:        "abc",
+	ClusterName:      "Shared Autoscaling",
+	SparkVersion:     "7.1-scala12",
+	NodeTypeID:       "i3.xlarge",
+	DriverNodeTypeID: "i3.2xlarge",
+	PolicyID:         "def",
+	State:            ClusterStateRunning,
+	JdbcPort:         10000,
+	ClusterCores:     4.5,
+	DefaultTags: map[string]string{
+		"Vendor": "Databricks",
+	},
+	AutoScale: &AutoScale{
+		MinWorkers: 1,
+		MaxWorkers: 4,
+	},
+	Driver: &SparkNode{
+		PrivateIP: "10.0.0.1",
+	},
+	Executors: []SparkNode{
+		{
+			PrivateIP: "10.0.0.2",
+		},
+	},
+}
+
+func TestDataSourceClusterByID(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/get?cluster_id=abc",
+				Response: testClusterInfo,
+			},
+		},
+		Resource:    DataSourceCluster(),
+		NonWritable: true,
+		Read:        true,
+		New:         true,
+		ID:          "_",
+		HCL:         `cluster_id = "abc"`,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, "Shared Autoscaling", d.Get("cluster_name"))
+	assert.Equal(t, "7.1-scala12", d.Get("cluster_info.0.spark_version"))
+	assert.Equal(t, "i3.2xlarge", d.Get("cluster_info.0.driver_node_type_id"))
+	assert.Equal(t, "def", d.Get("cluster_info.0.policy_id"))
+	assert.Equal(t, "RUNNING", d.Get("cluster_info.0.state"))
+	assert.Equal(t, 10000, d.Get("cluster_info.0.jdbc_port"))
+	assert.Equal(t, 4.5, d.Get("cluster_info.0.cluster_cores"))
+	assert.Equal(t, "Databricks", d.Get("cluster_info.0.default_tags.Vendor"))
+	assert.Equal(t, 4, d.Get("cluster_info.0.autoscale.0.max_workers"))
+	assert.Equal(t, "10.0.0.1", d.Get("cluster_info.0.driver.0.private_ip"))
+	assert.Equal(t, "10.0.0.2", d.Get("cluster_info.0.executors.0.private_ip"))
+}
+
+func TestDataSourceClusterByName(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/list",
+				Response: ClusterList{
+					Clusters: []ClusterInfo{
+						{
+							ClusterID:   "xyz",
+							ClusterName: "Shared",
+						},
+						testClusterInfo,
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/get?cluster_id=abc",
+				Response: testClusterInfo,
+			},
+		},
+		Resource:    DataSourceCluster(),
+		NonWritable: true,
+		Read:        true,
+		New:         true,
+		ID:          "_",
+		HCL:         `cluster_name = "Shared Autoscaling"`,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, "abc", d.Get("cluster_id"))
+}
+
+func TestDataSourceClusterByName_Ambiguous(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/list",
+				Response: ClusterList{
+					Clusters: []ClusterInfo{
+						{
+							ClusterID:   "a",
+							ClusterName: "Shared",
+						},
+						{
+							ClusterID:   "b",
+							ClusterName: "Shared",
+						},
+					},
+				},
+			},
+		},
+		Resource:    DataSourceCluster(),
+		NonWritable: true,
+		Read:        true,
+		New:         true,
+		ID:          "_",
+		HCL:         `cluster_name = "Shared"`,
+	}.ExpectError(t, "there are 2 clusters with name 'Shared': [a b]")
+}
+
+func TestDataSourceCluster_NotFound(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/list",
+				Response: ClusterList{},
+			},
+		},
+		Resource:    DataSourceCluster(),
+		NonWritable: true,
+		Read:        true,
+		New:         true,
+		ID:          "_",
+		HCL:         `cluster_name = "Shared"`,
+	}.ExpectError(t, "there is no cluster with name 'Shared'")
+}
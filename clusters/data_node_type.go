@@ -22,6 +22,17 @@ type NodeTypeRequest struct {
 	PhotonDriverCapable   bool   `json:"photon_driver_capable,omitempty"`
 	IsIOCacheEnabled      bool   `json:"is_io_cache_enabled,omitempty"`
 	SupportPortForwarding bool   `json:"support_port_forwarding,omitempty"`
+	Fleet                 bool   `json:"fleet,omitempty"`
+	Graviton              bool   `json:"graviton,omitempty"`
+	LocalDiskMinSize      int32  `json:"local_disk_min_size,omitempty"`
+	IsHidden              bool   `json:"is_hidden,omitempty"`
+	SupportEBSVolumes     bool   `json:"support_ebs_volumes,omitempty"`
+}
+
+// statuses of node types, that cannot be launched in the current workspace
+var unavailableNodeTypeStatuses = map[string]bool{
+	"NotAvailableInRegion":     true,
+	"NotEnabledOnSubscription": true,
 }
 
 // NodeTypeList contains a list of node types
@@ -97,8 +108,33 @@ type NodeType struct {
 	NodeInstanceType      *NodeInstanceType             `json:"node_instance_type,omitempty"`
 	PhotonWorkerCapable   bool                          `json:"photon_worker_capable,omitempty"`
 	PhotonDriverCapable   bool                          `json:"photon_driver_capable,omitempty"`
+	IsGraviton            bool                          `json:"is_graviton,omitempty"`
+}
+
+// isAvailable returns false for node types, that cannot be launched due to region or subscription
+func (nt NodeType) isAvailable() bool {
+	if nt.NodeInfo == nil {
+		return true
+	}
+	for _, status := range nt.NodeInfo.Status {
+		if unavailableNodeTypeStatuses[status] {
+			return false
+		}
+	}
+	return true
 }
 
+// localDiskSizeGB returns total size of local disks of the node type
+func (nt NodeType) localDiskSizeGB() int32 {
+	if nt.NodeInstanceType == nil {
+		return 0
+	}
+	return nt.NodeInstanceType.LocalDisks*nt.NodeInstanceType.LocalDiskSizeGB +
+		nt.NodeInstanceType.LocalNVMeDisks*nt.NodeInstanceType.LocalNVMeDiskSizeGB
+}
+
+// defaultSmallestNodeType returns general purpose node type, which is used
+// when node types cannot be listed or none of them matches
 func (a ClustersAPI) defaultSmallestNodeType() string {
 	if a.client.IsAzure() {
 		return "Standard_D3_v2"
@@ -114,58 +150,108 @@ func (a ClustersAPI) ListNodeTypes() (l NodeTypeList, err error) {
 	return
 }
 
-// GetSmallestNodeType returns smallest (or default) node type id given the criteria
+// GetSmallestNodeType returns smallest (or default) node type id given the criteria.
+// Unlike ListMatchingNodeTypes, it doesn't skip node types, that are not available
+// in the workspace region or subscription.
 func (a ClustersAPI) GetSmallestNodeType(r NodeTypeRequest) string {
 	list, _ := a.ListNodeTypes()
 	// error is explicitly ingored here, because Azure returns
 	// apparently too big of a JSON for Go to parse
-	if len(list.NodeTypes) == 0 {
+	matching := matchingNodeTypes(list, r, false)
+	if len(matching) == 0 {
 		return a.defaultSmallestNodeType()
 	}
+	return matching[0]
+}
+
+// ListMatchingNodeTypes returns ids of available node types, that match the criteria,
+// from the smallest to the largest. It's empty, if none matches or node types cannot be listed.
+func (a ClustersAPI) ListMatchingNodeTypes(r NodeTypeRequest) []string {
+	list, _ := a.ListNodeTypes()
+	return matchingNodeTypes(list, r, true)
+}
+
+// matchingNodeTypes returns ids of node types from the list, that match the criteria,
+// optionally skipping the ones, that cannot be launched in the current workspace
+func matchingNodeTypes(list NodeTypeList, r NodeTypeRequest, availableOnly bool) []string {
 	list.Sort()
+	matching := []string{}
 	for _, nt := range list.NodeTypes {
-		gbs := (nt.MemoryMB / 1024)
-		if r.MinMemoryGB > 0 && gbs < r.MinMemoryGB {
-			continue
-		}
-		if r.GBPerCore > 0 && (gbs/int32(nt.NumCores)) < r.GBPerCore {
+		if availableOnly && !nt.isAvailable() {
 			continue
 		}
-		if r.MinCores > 0 && int32(nt.NumCores) < r.MinCores {
-			continue
-		}
-		if r.MinGPUs > 0 && nt.NumGPUs < r.MinGPUs {
-			continue
-		}
-		if r.LocalDisk && nt.NodeInstanceType != nil &&
-			(nt.NodeInstanceType.LocalDisks < 1 &&
-				nt.NodeInstanceType.LocalNVMeDisks < 1) {
-			continue
-		}
-		if r.Category != "" && !strings.EqualFold(nt.Category, r.Category) {
-			continue
-		}
-		if r.IsIOCacheEnabled && nt.IsIOCacheEnabled != r.IsIOCacheEnabled {
-			continue
+		if r.matches(nt) {
+			matching = append(matching, nt.NodeTypeID)
 		}
-		if r.SupportPortForwarding && nt.SupportPortForwarding != r.SupportPortForwarding {
-			continue
-		}
-		if r.PhotonDriverCapable && nt.PhotonDriverCapable != r.PhotonDriverCapable {
-			continue
-		}
-		if r.PhotonWorkerCapable && nt.PhotonWorkerCapable != r.PhotonWorkerCapable {
-			continue
-		}
-		return nt.NodeTypeID
 	}
-	return a.defaultSmallestNodeType()
+	return matching
+}
+
+// matches checks if the node type satisfies all the criteria of the request
+func (r NodeTypeRequest) matches(nt NodeType) bool {
+	gbs := (nt.MemoryMB / 1024)
+	if r.MinMemoryGB > 0 && gbs < r.MinMemoryGB {
+		return false
+	}
+	if r.GBPerCore > 0 && (gbs/int32(nt.NumCores)) < r.GBPerCore {
+		return false
+	}
+	if r.MinCores > 0 && int32(nt.NumCores) < r.MinCores {
+		return false
+	}
+	if r.MinGPUs > 0 && nt.NumGPUs < r.MinGPUs {
+		return false
+	}
+	if r.LocalDisk && nt.NodeInstanceType != nil &&
+		(nt.NodeInstanceType.LocalDisks < 1 &&
+			nt.NodeInstanceType.LocalNVMeDisks < 1) {
+		return false
+	}
+	if r.LocalDiskMinSize > 0 && nt.localDiskSizeGB() < r.LocalDiskMinSize {
+		return false
+	}
+	if r.Category != "" && !strings.EqualFold(nt.Category, r.Category) {
+		return false
+	}
+	if r.IsIOCacheEnabled && nt.IsIOCacheEnabled != r.IsIOCacheEnabled {
+		return false
+	}
+	if r.SupportPortForwarding && nt.SupportPortForwarding != r.SupportPortForwarding {
+		return false
+	}
+	if r.PhotonDriverCapable && nt.PhotonDriverCapable != r.PhotonDriverCapable {
+		return false
+	}
+	if r.PhotonWorkerCapable && nt.PhotonWorkerCapable != r.PhotonWorkerCapable {
+		return false
+	}
+	if r.SupportEBSVolumes && nt.SupportEBSVolumes != r.SupportEBSVolumes {
+		return false
+	}
+	if r.IsHidden && nt.IsHidden != r.IsHidden {
+		return false
+	}
+	if r.Graviton && nt.IsGraviton != r.Graviton {
+		return false
+	}
+	// AWS fleet node types are named like `md-fleet.xlarge`
+	if r.Fleet && !strings.Contains(nt.NodeTypeID, "-fleet.") {
+		return false
+	}
+	return true
 }
 
 // DataSourceNodeType returns smallest node depedning on the cloud
 func DataSourceNodeType() *schema.Resource {
 	s := common.StructToSchema(NodeTypeRequest{}, func(
 		s map[string]*schema.Schema) map[string]*schema.Schema {
+		s["node_types"] = &schema.Schema{
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		}
 		return s
 	})
 	return &schema.Resource{
@@ -178,7 +264,13 @@ func DataSourceNodeType() *schema.Resource {
 				return diag.FromErr(err)
 			}
 			clustersAPI := NewClustersAPI(ctx, m)
-			d.SetId(clustersAPI.GetSmallestNodeType(this))
+			nodeTypes := clustersAPI.ListMatchingNodeTypes(this)
+			d.Set("node_types", nodeTypes)
+			if len(nodeTypes) == 0 {
+				d.SetId(clustersAPI.defaultSmallestNodeType())
+				return nil
+			}
+			d.SetId(nodeTypes[0])
 			return nil
 		},
 	}
@@ -1,6 +1,7 @@
 package clusters
 
 import (
+	"context"
 	"testing"
 
 	"github.com/databrickslabs/terraform-provider-databricks/common"
@@ -177,3 +178,216 @@ func TestSmallestNodeTypeClouds(t *testing.T) {
 		},
 	}.defaultSmallestNodeType())
 }
+
+func TestNodeTypeCandidates(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/list-node-types",
+				Response: NodeTypeList{
+					[]NodeType{
+						{
+							NodeTypeID:        "m6gd-fleet.2xlarge",
+							InstanceTypeID:    "m6gd.2xlarge",
+							MemoryMB:          32768,
+							NumCores:          8,
+							IsGraviton:        true,
+							SupportEBSVolumes: true,
+							NodeInstanceType: &NodeInstanceType{
+								LocalNVMeDisks:      1,
+								LocalNVMeDiskSizeGB: 474,
+							},
+						},
+						{
+							NodeTypeID:        "m6gd-fleet.xlarge",
+							InstanceTypeID:    "m6gd.xlarge",
+							MemoryMB:          16384,
+							NumCores:          4,
+							IsGraviton:        true,
+							SupportEBSVolumes: true,
+							NodeInstanceType: &NodeInstanceType{
+								LocalNVMeDisks:      1,
+								LocalNVMeDiskSizeGB: 237,
+							},
+						},
+						{
+							NodeTypeID:        "m6gd-fleet.large",
+							InstanceTypeID:    "m6gd.large",
+							MemoryMB:          8192,
+							NumCores:          2,
+							IsGraviton:        true,
+							SupportEBSVolumes: true,
+							NodeInstanceType: &NodeInstanceType{
+								LocalNVMeDisks:      1,
+								LocalNVMeDiskSizeGB: 118,
+							},
+						},
+						{
+							NodeTypeID:        "m6gd-fleet.4xlarge",
+							InstanceTypeID:    "m6gd.4xlarge",
+							MemoryMB:          65536,
+							NumCores:          16,
+							IsGraviton:        true,
+							SupportEBSVolumes: true,
+							NodeInfo: &ClusterCloudProviderNodeInfo{
+								Status: []string{"NotAvailableInRegion"},
+							},
+							NodeInstanceType: &NodeInstanceType{
+								LocalNVMeDisks:      1,
+								LocalNVMeDiskSizeGB: 950,
+							},
+						},
+						{
+							NodeTypeID:        "md-fleet.xlarge",
+							InstanceTypeID:    "m5d.xlarge",
+							MemoryMB:          16384,
+							NumCores:          4,
+							SupportEBSVolumes: true,
+							NodeInstanceType: &NodeInstanceType{
+								LocalNVMeDisks:      1,
+								LocalNVMeDiskSizeGB: 150,
+							},
+						},
+						{
+							NodeTypeID:     "m6gd.xlarge",
+							InstanceTypeID: "m6gd.xlarge",
+							MemoryMB:       16384,
+							NumCores:       4,
+							IsGraviton:     true,
+							NodeInstanceType: &NodeInstanceType{
+								LocalNVMeDisks:      1,
+								LocalNVMeDiskSizeGB: 237,
+							},
+						},
+					},
+				},
+			},
+		},
+		Read:        true,
+		Resource:    DataSourceNodeType(),
+		NonWritable: true,
+		HCL: `
+		fleet = true
+		graviton = true
+		support_ebs_volumes = true
+		local_disk_min_size = 200`,
+		ID: ".",
+	}.Apply(t)
+	assert.NoError(t, err)
+	assert.Equal(t, "m6gd-fleet.xlarge", d.Id())
+	assert.Equal(t, []interface{}{"m6gd-fleet.xlarge", "m6gd-fleet.2xlarge"}, d.Get("node_types"))
+}
+
+func TestNodeTypeFallback(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/list-node-types",
+				Response: NodeTypeList{
+					[]NodeType{
+						{
+							NodeTypeID:     "i3.xlarge",
+							InstanceTypeID: "i3.xlarge",
+							MemoryMB:       31232,
+							NumCores:       4,
+						},
+					},
+				},
+			},
+		},
+		Read:        true,
+		Resource:    DataSourceNodeType(),
+		NonWritable: true,
+		HCL:         `is_hidden = true`,
+		ID:          ".",
+	}.Apply(t)
+	assert.NoError(t, err)
+	assert.Equal(t, "i3.xlarge", d.Id())
+	// cloud default doesn't match the criteria, so it's not a candidate
+	assert.Equal(t, []interface{}{}, d.Get("node_types"))
+}
+
+func TestNodeTypeUnavailableSmallest(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/list-node-types",
+				Response: NodeTypeList{
+					[]NodeType{
+						{
+							NodeTypeID:     "g4dn.xlarge",
+							InstanceTypeID: "g4dn.xlarge",
+							MemoryMB:       16384,
+							NumCores:       4,
+							NumGPUs:        1,
+							NodeInfo: &ClusterCloudProviderNodeInfo{
+								Status: []string{"NotAvailableInRegion"},
+							},
+						},
+						{
+							NodeTypeID:     "g4dn.2xlarge",
+							InstanceTypeID: "g4dn.2xlarge",
+							MemoryMB:       32768,
+							NumCores:       8,
+							NumGPUs:        1,
+						},
+						{
+							NodeTypeID:     "m5d.large",
+							InstanceTypeID: "m5d.large",
+							MemoryMB:       8192,
+							NumCores:       2,
+						},
+					},
+				},
+			},
+		},
+		Read:        true,
+		Resource:    DataSourceNodeType(),
+		NonWritable: true,
+		HCL:         `min_gpus = 1`,
+		ID:          ".",
+	}.Apply(t)
+	assert.NoError(t, err)
+	assert.Equal(t, "g4dn.2xlarge", d.Id())
+	assert.Equal(t, []interface{}{"g4dn.2xlarge"}, d.Get("node_types"))
+}
+
+func TestSmallestNodeType_Unavailable(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/clusters/list-node-types",
+			Response: NodeTypeList{
+				[]NodeType{
+					{
+						NodeTypeID:     "m5d.large",
+						InstanceTypeID: "m5d.large",
+						MemoryMB:       8192,
+						NumCores:       2,
+						NodeInfo: &ClusterCloudProviderNodeInfo{
+							Status: []string{"NotEnabledOnSubscription"},
+						},
+					},
+					{
+						NodeTypeID:     "m5d.xlarge",
+						InstanceTypeID: "m5d.xlarge",
+						MemoryMB:       16384,
+						NumCores:       4,
+					},
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		api := NewClustersAPI(ctx, client)
+		// existing callers keep getting the smallest node type
+		assert.Equal(t, "m5d.large", api.GetSmallestNodeType(NodeTypeRequest{}))
+		assert.Equal(t, []string{"m5d.xlarge"}, api.ListMatchingNodeTypes(NodeTypeRequest{}))
+	})
+}
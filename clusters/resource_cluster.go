@@ -2,9 +2,13 @@ package clusters
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
@@ -17,6 +21,18 @@ const DefaultProvisionTimeout = 30 * time.Minute
 
 var clusterSchema = resourceClusterSchema()
 
+// clusterInfoSchema reads everything from ClusterInfo, except the state, because
+// configured state is the desired one and is set separately
+var clusterInfoSchema = func() map[string]*schema.Schema {
+	s := map[string]*schema.Schema{}
+	for k, v := range clusterSchema {
+		if k != "state" {
+			s[k] = v
+		}
+	}
+	return s
+}()
+
 // ResourceCluster - returns Cluster resource description
 func ResourceCluster() *schema.Resource {
 	return common.Resource{
@@ -27,6 +43,20 @@ func ResourceCluster() *schema.Resource {
 			d *schema.ResourceData, c *common.DatabricksClient) error {
 			return NewClustersAPI(ctx, c).PermanentDelete(d.Id())
 		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, c interface{}) error {
+			var cluster Cluster
+			if err := common.DiffToStructPointer(d, clusterSchema, &cluster); err != nil {
+				return err
+			}
+			return ValidatePolicyCompliance(ctx, c.(*common.DatabricksClient),
+				cluster, ClusterTypeAllPurpose, func(attr string) bool {
+					if cluster.ApplyPolicyDefaultValues && isSchemaDefault(d, attr) {
+						// policy value is used instead of the schema default
+						return false
+					}
+					return d.NewValueKnown(attr)
+				})
+		},
 		Schema:        clusterSchema,
 		SchemaVersion: 2,
 		Timeouts: &schema.ResourceTimeout{
@@ -66,8 +96,40 @@ func resourceClusterSchema() map[string]*schema.Schema {
 					lib := libraries.NewLibraryFromInstanceState(i)
 					return schema.HashString(lib.String())
 				}
+				// not a part of API request, so that it's not in the libraries.Library
+				ss["library"].Elem.(*schema.Resource).Schema["on_failure"] = &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+					Default:  libraries.OnFailureFail,
+					ValidateFunc: validation.StringInSlice([]string{
+						libraries.OnFailureFail,
+						libraries.OnFailureWarn,
+						libraries.OnFailureUninstall,
+					}, false),
+				}
 				return ss
 			})["library"]
+		s["library_status"] = &schema.Schema{
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"library": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"status": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"messages": {
+						Type:     schema.TypeList,
+						Computed: true,
+						Elem:     &schema.Schema{Type: schema.TypeString},
+					},
+				},
+			},
+		}
 
 		p, err := common.SchemaPath(s, "docker_image", "basic_auth", "password")
 		if err == nil {
@@ -100,7 +162,12 @@ func resourceClusterSchema() map[string]*schema.Schema {
 		}
 		s["state"] = &schema.Schema{
 			Type:     schema.TypeString,
+			Optional: true,
 			Computed: true,
+			ValidateFunc: validation.StringInSlice([]string{
+				ClusterStateRunning,
+				ClusterStateTerminated,
+			}, false),
 		}
 		s["default_tags"] = &schema.Schema{
 			Type:     schema.TypeMap,
@@ -129,6 +196,9 @@ func resourceClusterCreate(ctx context.Context, d *schema.ResourceData, c *commo
 	if err != nil {
 		return err
 	}
+	if err = applyPolicyDefaultValues(clusters, d, &cluster); err != nil {
+		return err
+	}
 	if err = cluster.Validate(); err != nil {
 		return err
 	}
@@ -156,19 +226,216 @@ func resourceClusterCreate(ctx context.Context, d *schema.ResourceData, c *commo
 		if err = libs.Install(libraryList); err != nil {
 			return err
 		}
-		_, err := libs.WaitForLibrariesInstalled(libraries.Wait{
+		onFailure := libraryFailurePolicies(d)
+		libsClusterStatus, err := libs.WaitForLibrariesInstalled(libraries.Wait{
 			ClusterID: d.Id(),
 			Timeout:   timeout - time.Since(start),
 			IsRunning: clusterInfo.IsRunningOrResizing(),
 			IsRefresh: false,
+			OnFailure: onFailure,
 		})
 		if err != nil {
 			return err
 		}
+		if err = setLibraryStatuses(d, libsClusterStatus.LibraryStatuses); err != nil {
+			return err
+		}
+		if clusterInfo.IsRunningOrResizing() {
+			err = restartIfLibrariesRequireIt(ctx, c, d.Id(), timeout-time.Since(start), onFailure)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if d.Get("state").(string) == ClusterStateTerminated {
+		return clusters.Terminate(d.Id())
 	}
 	return nil
 }
 
+// isConvergingTo returns true, if cluster is in transition to the desired state
+func (state ClusterState) isConvergingTo(desired ClusterState) bool {
+	switch state {
+	case ClusterStatePending, ClusterStateResizing, ClusterStateRestarting:
+		return desired == ClusterStateRunning
+	case ClusterStateTerminating:
+		return desired == ClusterStateTerminated
+	}
+	return false
+}
+
+// ensureClusterState starts or terminates cluster, so that it reaches the desired state
+func ensureClusterState(clusters ClustersAPI, clusterID string, desired ClusterState) error {
+	info, err := clusters.Get(clusterID)
+	if err != nil {
+		return err
+	}
+	switch {
+	case info.State == desired:
+		return nil
+	case desired == ClusterStateRunning:
+		_, err = clusters.StartAndGetInfo(clusterID)
+		return err
+	case desired == ClusterStateTerminated && info.State.CanReach(desired):
+		log.Printf("[INFO] Terminating cluster %s in %s state", clusterID, info.State)
+		return clusters.Terminate(clusterID)
+	}
+	return fmt.Errorf("cluster %s cannot reach %s state from %s", clusterID, desired, info.State)
+}
+
+// libraryFailurePolicies returns `on_failure` of every `library` block by library string representation
+func libraryFailurePolicies(d *schema.ResourceData) map[string]string {
+	policies := map[string]string{}
+	libs, ok := d.Get("library").(*schema.Set)
+	if !ok {
+		return policies
+	}
+	for _, v := range libs.List() {
+		raw := v.(map[string]interface{})
+		policy, _ := raw["on_failure"].(string)
+		policies[libraries.NewLibraryFromInstanceState(raw).String()] = policy
+	}
+	return policies
+}
+
+// setLibraryFailurePolicies keeps `on_failure` on `library` blocks, as it's not returned by API
+func setLibraryFailurePolicies(d *schema.ResourceData, policies map[string]string) error {
+	libs, ok := d.Get("library").(*schema.Set)
+	if !ok || libs.Len() == 0 {
+		return nil
+	}
+	withPolicies := []interface{}{}
+	for _, v := range libs.List() {
+		raw := v.(map[string]interface{})
+		policy := policies[libraries.NewLibraryFromInstanceState(raw).String()]
+		if policy == "" {
+			policy = libraries.OnFailureFail
+		}
+		raw["on_failure"] = policy
+		withPolicies = append(withPolicies, raw)
+	}
+	return d.Set("library", withPolicies)
+}
+
+// setLibraryStatuses sets `library_status` for all libraries, that are not installed on all clusters
+func setLibraryStatuses(d *schema.ResourceData, statuses []libraries.LibraryStatus) error {
+	libraryStatuses := []interface{}{}
+	for _, v := range statuses {
+		if v.IsGlobal {
+			continue
+		}
+		libraryStatuses = append(libraryStatuses, map[string]interface{}{
+			"library":  v.Library.String(),
+			"status":   v.Status,
+			"messages": v.Messages,
+		})
+	}
+	return d.Set("library_status", libraryStatuses)
+}
+
+// uninstalledFailures returns configured libraries, that previously failed to install
+// and were removed from the cluster by the `uninstall` policy, with their statuses
+func uninstalledFailures(d *schema.ResourceData) map[string]libraries.LibraryStatus {
+	failures := map[string]libraries.LibraryStatus{}
+	libs, ok := d.Get("library").(*schema.Set)
+	if !ok {
+		return failures
+	}
+	configured := map[string]map[string]interface{}{}
+	for _, v := range libs.List() {
+		raw := v.(map[string]interface{})
+		if raw["on_failure"] == libraries.OnFailureUninstall {
+			configured[libraries.NewLibraryFromInstanceState(raw).String()] = raw
+		}
+	}
+	for _, v := range d.Get("library_status").([]interface{}) {
+		status := v.(map[string]interface{})
+		name := status["library"].(string)
+		raw, ok := configured[name]
+		if !ok || status["status"] != "FAILED" {
+			continue
+		}
+		lib := libraries.NewLibraryFromInstanceState(raw)
+		failure := libraries.LibraryStatus{
+			Library: &lib,
+			Status:  "FAILED",
+		}
+		for _, m := range status["messages"].([]interface{}) {
+			failure.Messages = append(failure.Messages, m.(string))
+		}
+		failures[name] = failure
+	}
+	return failures
+}
+
+// withUninstalledFailures keeps libraries, that were removed by the `uninstall` policy, in statuses,
+// so that the failure stays visible and the library is not installed again on the next apply
+func withUninstalledFailures(d *schema.ResourceData, statuses []libraries.LibraryStatus,
+	onFailure map[string]string) []libraries.LibraryStatus {
+	failures := uninstalledFailures(d)
+	result := []libraries.LibraryStatus{}
+	for _, v := range statuses {
+		name := v.Library.String()
+		if _, ok := failures[name]; ok && v.Status == "UNINSTALL_ON_RESTART" {
+			// removed by the policy, but cluster was not restarted yet
+			continue
+		}
+		delete(failures, name)
+		result = append(result, v)
+	}
+	names := []string{}
+	for name := range failures {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if onFailure[name] == libraries.OnFailureUninstall {
+			result = append(result, failures[name])
+		}
+	}
+	return result
+}
+
+// withoutUninstalledFailures skips installation of libraries, that were removed by the `uninstall` policy
+func withoutUninstalledFailures(d *schema.ResourceData, libs []libraries.Library) []libraries.Library {
+	failures := uninstalledFailures(d)
+	result := []libraries.Library{}
+	for _, lib := range libs {
+		if _, ok := failures[lib.String()]; ok {
+			log.Printf("[INFO] Not installing %s again, as it failed before", lib)
+			continue
+		}
+		result = append(result, lib)
+	}
+	return result
+}
+
+// restartIfLibrariesRequireIt restarts a running cluster, when some of the libraries could
+// be uninstalled only with a restart, and waits for the remaining libraries to be installed
+func restartIfLibrariesRequireIt(ctx context.Context, c *common.DatabricksClient,
+	clusterID string, timeout time.Duration, onFailure map[string]string) error {
+	librariesAPI := libraries.NewLibrariesAPI(ctx, c)
+	libsClusterStatus, err := librariesAPI.ClusterStatus(clusterID)
+	if err != nil {
+		return err
+	}
+	if !libsClusterStatus.IsRestartNeeded() {
+		return nil
+	}
+	log.Printf("[INFO] Restarting %s to complete uninstallation of libraries", clusterID)
+	if err = NewClustersAPI(ctx, c).Restart(clusterID); err != nil {
+		return err
+	}
+	_, err = librariesAPI.WaitForLibrariesInstalled(libraries.Wait{
+		ClusterID: clusterID,
+		Timeout:   timeout,
+		IsRunning: true,
+		IsRefresh: false,
+		OnFailure: onFailure,
+	})
+	return err
+}
+
 func setPinnedStatus(d *schema.ResourceData, clusterAPI ClustersAPI) error {
 	events, err := clusterAPI.Events(EventsRequest{
 		ClusterID:  d.Id(),
@@ -193,31 +460,115 @@ func resourceClusterRead(ctx context.Context, d *schema.ResourceData, c *common.
 	if err != nil {
 		return err
 	}
-	if err = common.StructToData(clusterInfo, clusterSchema, d); err != nil {
+	if err = removePolicyPresets(d, clusterAPI, &clusterInfo); err != nil {
 		return err
 	}
+	desired := ClusterState(d.Get("state").(string))
+	if err = common.StructToData(clusterInfo, clusterInfoSchema, d); err != nil {
+		return err
+	}
+	state := clusterInfo.State
+	if state.isConvergingTo(desired) {
+		// don't report drift, while cluster is on its way to the desired state
+		state = desired
+	}
+	d.Set("state", state)
 	if err = setPinnedStatus(d, clusterAPI); err != nil {
 		return err
 	}
 	d.Set("url", c.FormatURL("#setting/clusters/", d.Id(), "/configuration"))
 	librariesAPI := libraries.NewLibrariesAPI(ctx, c)
+	onFailure := libraryFailurePolicies(d)
 	libsClusterStatus, err := librariesAPI.WaitForLibrariesInstalled(libraries.Wait{
 		ClusterID: d.Id(),
 		Timeout:   d.Timeout(schema.TimeoutRead),
 		IsRunning: clusterInfo.IsRunningOrResizing(),
 		IsRefresh: true,
+		OnFailure: onFailure,
 	})
 	if err != nil {
 		return err
 	}
+	libsClusterStatus.LibraryStatuses = withUninstalledFailures(d,
+		libsClusterStatus.LibraryStatuses, onFailure)
+	if err = setLibraryStatuses(d, libsClusterStatus.LibraryStatuses); err != nil {
+		return err
+	}
 	libList := libsClusterStatus.ToLibraryList()
-	return common.StructToData(libList, clusterSchema, d)
+	if err = common.StructToData(libList, clusterSchema, d); err != nil {
+		return err
+	}
+	return setLibraryFailurePolicies(d, onFailure)
+}
+
+// rawConfigGetter is implemented by both schema.ResourceData and schema.ResourceDiff
+type rawConfigGetter interface {
+	Get(key string) interface{}
+	GetRawConfig() cty.Value
+}
+
+// isSchemaDefault returns true, if top-level attribute has the schema default and is not
+// explicitly configured. Configuration is not available during refresh, so the value equal
+// to the schema default is treated as not configured.
+func isSchemaDefault(d rawConfigGetter, attr string) bool {
+	s, ok := clusterSchema[attr]
+	if !ok || s.Default == nil {
+		return false
+	}
+	config := d.GetRawConfig()
+	if !config.IsNull() && config.IsKnown() {
+		return config.GetAttr(attr).IsNull()
+	}
+	return d.Get(attr) == s.Default
+}
+
+// applyPolicyDefaultValues merges values of cluster policy, treating `autotermination_minutes`,
+// that only has the schema default, as not specified
+func applyPolicyDefaultValues(clusters ClustersAPI, d rawConfigGetter, cluster *Cluster) error {
+	autotermination := cluster.AutoterminationMinutes
+	if cluster.ApplyPolicyDefaultValues && isSchemaDefault(d, "autotermination_minutes") {
+		cluster.AutoterminationMinutes = 0
+	}
+	if err := clusters.ApplyPolicyDefaultValues(cluster); err != nil {
+		return err
+	}
+	if cluster.AutoterminationMinutes == 0 {
+		// policy doesn't set it, so the schema default is used
+		cluster.AutoterminationMinutes = autotermination
+	}
+	return nil
+}
+
+// removePolicyPresets ignores values, that were set by the cluster policy and not in configuration,
+// so that clusters with `apply_policy_default_values` don't report drift
+func removePolicyPresets(d *schema.ResourceData, clusterAPI ClustersAPI, clusterInfo *ClusterInfo) error {
+	var cluster Cluster
+	if err := common.DataToStructPointer(d, clusterSchema, &cluster); err != nil {
+		return err
+	}
+	autotermination := cluster.AutoterminationMinutes
+	isDefault := cluster.ApplyPolicyDefaultValues && isSchemaDefault(d, "autotermination_minutes")
+	if isDefault {
+		cluster.AutoterminationMinutes = 0
+	}
+	err := clusterAPI.RemovePolicyPresets(cluster, clusterInfo, func(path string) bool {
+		s, ok := clusterSchema[strings.SplitN(path, ".", 2)[0]]
+		return ok && s.Computed
+	})
+	if err != nil {
+		return err
+	}
+	if isDefault && clusterInfo.AutoterminationMinutes == 0 {
+		// keep the schema default, so that it's not reported as drift
+		clusterInfo.AutoterminationMinutes = autotermination
+	}
+	return nil
 }
 
 func hasClusterConfigChanged(d *schema.ResourceData) bool {
 	for k := range clusterSchema {
 		// TODO: create a map if we'll add more non-cluster config parameters in the future
-		if k == "library" || k == "is_pinned" {
+		if k == "library" || k == "library_status" || k == "is_pinned" || k == "state" {
 			continue
 		}
 		if d.HasChange(k) {
@@ -249,6 +600,9 @@ func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, c *commo
 	var clusterInfo ClusterInfo
 	if hasClusterConfigChanged(d) {
 		log.Printf("[DEBUG] Cluster state has changed!")
+		if err = applyPolicyDefaultValues(clusters, d, &cluster); err != nil {
+			return err
+		}
 		if err = cluster.Validate(); err != nil {
 			return err
 		}
@@ -288,6 +642,7 @@ func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, c *commo
 	}
 	libraryList.ClusterID = clusterID
 	libsToInstall, libsToUninstall := libraryList.Diff(libsClusterStatus)
+	libsToInstall.Libraries = withoutUninstalledFailures(d, libsToInstall.Libraries)
 	if len(libsToUninstall.Libraries) > 0 || len(libsToInstall.Libraries) > 0 {
 		if !clusterInfo.IsRunningOrResizing() {
 			if _, err = clusters.StartAndGetInfo(clusterID); err != nil {
@@ -296,17 +651,31 @@ func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, c *commo
 		}
 		// clusters.StartAndGetInfo() always returns a running cluster
 		// or errors out, so we just know the cluster is active.
-		err = librariesAPI.UpdateLibraries(clusterID, libsToInstall, libsToUninstall,
-			d.Timeout(schema.TimeoutUpdate))
+		onFailure := libraryFailurePolicies(d)
+		updated, err := librariesAPI.UpdateLibraries(clusterID, libsToInstall, libsToUninstall,
+			d.Timeout(schema.TimeoutUpdate), onFailure)
 		if err != nil {
 			return err
 		}
-		if clusterInfo.State == ClusterStateTerminated {
+		updated.LibraryStatuses = withUninstalledFailures(d, updated.LibraryStatuses, onFailure)
+		if err = setLibraryStatuses(d, updated.LibraryStatuses); err != nil {
+			return err
+		}
+		if clusterInfo.State == ClusterStateTerminated && !d.HasChange("state") {
+			// libraries marked for uninstallation are removed on the next start
 			log.Printf("[INFO] %s was in TERMINATED state, so terminating it again", clusterID)
 			if err = clusters.Terminate(clusterID); err != nil {
 				return err
 			}
+			return nil
 		}
+		err = restartIfLibrariesRequireIt(ctx, c, clusterID, d.Timeout(schema.TimeoutUpdate), onFailure)
+		if err != nil {
+			return err
+		}
+	}
+	if d.HasChange("state") {
+		return ensureClusterState(clusters, clusterID, ClusterState(d.Get("state").(string)))
 	}
 	return nil
 }
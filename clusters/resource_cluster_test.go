@@ -1,14 +1,19 @@
 package clusters
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/databrickslabs/terraform-provider-databricks/common"
 	"github.com/databrickslabs/terraform-provider-databricks/libraries"
+	"github.com/databrickslabs/terraform-provider-databricks/policies"
 
 	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -292,8 +297,9 @@ func TestResourceClusterCreate_WithLibraries(t *testing.T) {
 			},
 			{
 				Method: "GET",
-				// 3 of 3 requests
-				Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				// 3rd and 4th requests, as we check if restart is required
+				Resource:     "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				ReuseRequest: true,
 				Response: libraries.ClusterLibraryStatuses{
 					LibraryStatuses: []libraries.LibraryStatus{
 						{
@@ -1296,3 +1302,795 @@ func TestRefreshOnRunningClusterWithFailedLibraryUninstallsIt(t *testing.T) {
 		ID:   "foo",
 	}.ApplyNoError(t)
 }
+
+func TestRefreshOnRunningClusterWithFailedLibraryAndWarnPolicy(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Resource: ResourceCluster(),
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/get?cluster_id=foo",
+				Response: ClusterInfo{
+					State: ClusterStateRunning,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=foo",
+				Response: libraries.ClusterLibraryStatuses{
+					ClusterID: "foo",
+					LibraryStatuses: []libraries.LibraryStatus{
+						{
+							Status:   "FAILED",
+							Messages: []string{"fails for the test"},
+							Library: &libraries.Library{
+								Jar: "foo.bar",
+							},
+						},
+					},
+				},
+			},
+		},
+		State: map[string]interface{}{
+			"spark_version": "7.1-scala12",
+			"library": []interface{}{
+				map[string]interface{}{
+					"jar":        "foo.bar",
+					"on_failure": "warn",
+				},
+			},
+		},
+		Read: true,
+		ID:   "foo",
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, 1, d.Get("library.#"))
+	lib := d.Get("library").(*schema.Set).List()[0].(map[string]interface{})
+	assert.Equal(t, "warn", lib["on_failure"])
+	assert.Equal(t, "jar:foo.bar", d.Get("library_status.0.library"))
+	assert.Equal(t, "FAILED", d.Get("library_status.0.status"))
+	assert.Equal(t, "fails for the test", d.Get("library_status.0.messages.0"))
+}
+
+func TestResourceClusterUpdate_LibraryUninstallRequiresRestart(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+				ReuseRequest: true,
+				Response: ClusterInfo{
+					ClusterID:              "abc",
+					NumWorkers:             100,
+					SparkVersion:           "7.1-scala12",
+					NodeTypeID:             "i3.xlarge",
+					AutoterminationMinutes: 60,
+					State:                  ClusterStateRunning,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: libraries.ClusterLibraryStatuses{
+					ClusterID: "abc",
+					LibraryStatuses: []libraries.LibraryStatus{
+						{
+							Library: &libraries.Library{
+								Jar: "dbfs://old.jar",
+							},
+							Status: "INSTALLED",
+						},
+					},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/libraries/uninstall",
+				ExpectedRequest: libraries.ClusterLibraryList{
+					ClusterID: "abc",
+					Libraries: []libraries.Library{
+						{
+							Jar: "dbfs://old.jar",
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: libraries.ClusterLibraryStatuses{
+					ClusterID: "abc",
+					LibraryStatuses: []libraries.LibraryStatus{
+						{
+							Library: &libraries.Library{
+								Jar: "dbfs://old.jar",
+							},
+							Status: "UNINSTALL_ON_RESTART",
+						},
+					},
+				},
+			},
+			{
+				// restart check sees the same pending uninstall
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: libraries.ClusterLibraryStatuses{
+					ClusterID: "abc",
+					LibraryStatuses: []libraries.LibraryStatus{
+						{
+							Library: &libraries.Library{
+								Jar: "dbfs://old.jar",
+							},
+							Status: "UNINSTALL_ON_RESTART",
+						},
+					},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/restart",
+				ExpectedRequest: ClusterID{
+					ClusterID: "abc",
+				},
+			},
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				ReuseRequest: true,
+				Response: libraries.ClusterLibraryStatuses{
+					ClusterID:       "abc",
+					LibraryStatuses: []libraries.LibraryStatus{},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				Response: EventsResponse{
+					Events:     []ClusterEvent{},
+					TotalCount: 0,
+				},
+			},
+		},
+		ID:       "abc",
+		Update:   true,
+		Resource: ResourceCluster(),
+		InstanceState: map[string]string{
+			"autotermination_minutes": "60",
+			"cluster_id":              "abc",
+			"num_workers":             "100",
+			"spark_version":           "7.1-scala12",
+			"node_type_id":            "i3.xlarge",
+		},
+		HCL: `num_workers = 100
+		spark_version = "7.1-scala12"
+		node_type_id = "i3.xlarge"`,
+	}.ApplyNoError(t)
+}
+
+func TestResourceClusterCreate_PolicyViolations(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/policies/clusters/get?policy_id=abc",
+				Response: policies.ClusterPolicy{
+					PolicyID: "abc",
+					Name:     "Team",
+					Definition: `{
+						"cluster_type": {"type": "fixed", "value": "all-purpose"},
+						"node_type_id": {"type": "allowlist", "values": ["i3.xlarge"]},
+						"driver_node_type_id": {"type": "allowlist", "values": ["i3.xlarge"]},
+						"autotermination_minutes": {"type": "range", "maxValue": 60},
+						"custom_tags.team": {"type": "unlimited"},
+						"workload_type.clients.jobs": {"type": "forbidden"},
+						"dbus_per_hour": {"type": "range", "maxValue": 10}
+					}`,
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `
+		policy_id = "abc"
+		spark_version = "7.1-scala12"
+		node_type_id = "m4.large"
+		autotermination_minutes = 120
+		num_workers = 1`,
+	}.ExpectError(t, "cluster does not comply with policy abc: "+
+		"autotermination_minutes must be at most 60, but is 120; "+
+		"custom_tags.team is required by policy; "+
+		"node_type_id must be one of i3.xlarge, but is m4.large")
+}
+
+func TestValidatePolicyCompliance_CannotReadPolicy(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/policies/clusters/get?policy_id=abc",
+			Status:   403,
+			Response: common.APIErrorBody{
+				ErrorCode: "PERMISSION_DENIED",
+				Message:   "User is not allowed to read the policy",
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/policies/clusters/get?policy_id=def",
+			Status:   404,
+			Response: common.APIErrorBody{
+				ErrorCode: "RESOURCE_DOES_NOT_EXIST",
+				Message:   "Policy does not exist",
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		isKnown := func(string) bool { return true }
+		for _, policyID := range []string{"abc", "def"} {
+			err := ValidatePolicyCompliance(ctx, client, Cluster{
+				PolicyID:   policyID,
+				NumWorkers: 100,
+			}, ClusterTypeAllPurpose, isKnown)
+			assert.NoError(t, err, policyID)
+		}
+	})
+}
+
+func TestValidatePolicyCompliance_HostFromProfile(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/policies/clusters/get?policy_id=abc",
+			Response: policies.ClusterPolicy{
+				PolicyID:   "abc",
+				Definition: `{"num_workers": {"type": "range", "maxValue": 10}}`,
+			},
+		},
+	})
+	require.NoError(t, err)
+	defer server.Close()
+	configFile := filepath.Join(t.TempDir(), ".databrickscfg")
+	err = os.WriteFile(configFile, []byte(fmt.Sprintf("[DEFAULT]\nhost = %s\ntoken = %s\n",
+		client.Host, client.Token)), 0600)
+	require.NoError(t, err)
+	profileClient := &common.DatabricksClient{ConfigFile: configFile}
+	err = profileClient.Configure()
+	require.NoError(t, err)
+
+	err = ValidatePolicyCompliance(context.Background(), profileClient, Cluster{
+		PolicyID:   "abc",
+		NumWorkers: 100,
+	}, ClusterTypeAllPurpose, func(string) bool { return true })
+	assert.EqualError(t, err, "cluster does not comply with policy abc: "+
+		"num_workers must be at most 10, but is 100")
+}
+
+func TestValidatePolicyCompliance_NotAuthenticated(t *testing.T) {
+	err := ValidatePolicyCompliance(context.Background(), &common.DatabricksClient{
+		ConfigFile: filepath.Join(t.TempDir(), ".databrickscfg"),
+	}, Cluster{
+		PolicyID:   "abc",
+		NumWorkers: 100,
+	}, ClusterTypeAllPurpose, func(string) bool { return true })
+	assert.NoError(t, err)
+}
+
+func TestResourceClusterCreate_ApplyPolicyDefaultValues(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/policies/clusters/get?policy_id=abc",
+				ReuseRequest: true,
+				Response: policies.ClusterPolicy{
+					PolicyID: "abc",
+					Definition: `{
+						"custom_tags.Team": {"type": "fixed", "value": "data"},
+						"spark_conf.spark.databricks.io.cache.enabled": {"type": "fixed", "value": true},
+						"node_type_id": {"type": "allowlist", "values": ["i3.xlarge"], "defaultValue": "i3.xlarge"},
+						"autoscale.max_workers": {"type": "range", "maxValue": 10, "defaultValue": 4}
+					}`,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/create",
+				ExpectedRequest: Cluster{
+					PolicyID:                 "abc",
+					ApplyPolicyDefaultValues: true,
+					NumWorkers:               2,
+					SparkVersion:             "7.1-scala12",
+					NodeTypeID:               "i3.xlarge",
+					AutoterminationMinutes:   60,
+					CustomTags: map[string]string{
+						"Team": "data",
+					},
+					SparkConf: map[string]string{
+						"spark.databricks.io.cache.enabled": "true",
+					},
+				},
+				Response: ClusterInfo{
+					ClusterID: "abc",
+					State:     ClusterStateRunning,
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+				Response: ClusterInfo{
+					ClusterID:              "abc",
+					PolicyID:               "abc",
+					NumWorkers:             2,
+					SparkVersion:           "7.1-scala12",
+					NodeTypeID:             "i3.xlarge",
+					AutoterminationMinutes: 60,
+					CustomTags: map[string]string{
+						"Team": "data",
+					},
+					SparkConf: map[string]string{
+						"spark.databricks.io.cache.enabled": "true",
+					},
+					State: ClusterStateRunning,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				Response: EventsResponse{
+					Events:     []ClusterEvent{},
+					TotalCount: 0,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: libraries.ClusterLibraryStatuses{
+					LibraryStatuses: []libraries.LibraryStatus{},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `
+		policy_id = "abc"
+		apply_policy_default_values = true
+		spark_version = "7.1-scala12"
+		num_workers = 2`,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, "i3.xlarge", d.Get("node_type_id"))
+	assert.Len(t, d.Get("custom_tags"), 0)
+	assert.Len(t, d.Get("spark_conf"), 0)
+}
+
+func TestResourceClusterCreate_PolicyFixedAutotermination(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/policies/clusters/get?policy_id=abc",
+				ReuseRequest: true,
+				Response: policies.ClusterPolicy{
+					PolicyID: "abc",
+					Definition: `{
+						"autotermination_minutes": {"type": "fixed", "value": 30, "hidden": true}
+					}`,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/create",
+				ExpectedRequest: Cluster{
+					PolicyID:                 "abc",
+					ApplyPolicyDefaultValues: true,
+					NumWorkers:               2,
+					SparkVersion:             "7.1-scala12",
+					NodeTypeID:               "i3.xlarge",
+					AutoterminationMinutes:   30,
+				},
+				Response: ClusterInfo{
+					ClusterID: "abc",
+					State:     ClusterStateRunning,
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+				Response: ClusterInfo{
+					ClusterID:              "abc",
+					PolicyID:               "abc",
+					NumWorkers:             2,
+					SparkVersion:           "7.1-scala12",
+					NodeTypeID:             "i3.xlarge",
+					AutoterminationMinutes: 30,
+					State:                  ClusterStateRunning,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				Response: EventsResponse{
+					Events:     []ClusterEvent{},
+					TotalCount: 0,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: libraries.ClusterLibraryStatuses{
+					LibraryStatuses: []libraries.LibraryStatus{},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `
+		policy_id = "abc"
+		apply_policy_default_values = true
+		spark_version = "7.1-scala12"
+		node_type_id = "i3.xlarge"
+		num_workers = 2`,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	// schema default is kept in the state, so that policy value is not reported as drift
+	assert.Equal(t, 60, d.Get("autotermination_minutes"))
+}
+
+func TestResourceClusterCreate_PolicyFixedAutoterminationConfigured(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/policies/clusters/get?policy_id=abc",
+				Response: policies.ClusterPolicy{
+					PolicyID: "abc",
+					Definition: `{
+						"autotermination_minutes": {"type": "fixed", "value": 30}
+					}`,
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `
+		policy_id = "abc"
+		apply_policy_default_values = true
+		spark_version = "7.1-scala12"
+		node_type_id = "i3.xlarge"
+		autotermination_minutes = 60
+		num_workers = 2`,
+	}.ExpectError(t, "cluster does not comply with policy abc: "+
+		"autotermination_minutes must be 30, but is 60")
+}
+
+func TestResourceClusterCreate_Terminated(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/create",
+				ExpectedRequest: Cluster{
+					NumWorkers:             1,
+					SparkVersion:           "7.1-scala12",
+					NodeTypeID:             "i3.xlarge",
+					AutoterminationMinutes: 60,
+				},
+				Response: ClusterInfo{
+					ClusterID: "abc",
+					State:     ClusterStateRunning,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/get?cluster_id=abc",
+				Response: ClusterInfo{
+					ClusterID: "abc",
+					State:     ClusterStateRunning,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/delete",
+				ExpectedRequest: ClusterID{
+					ClusterID: "abc",
+				},
+			},
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+				ReuseRequest: true,
+				Response: ClusterInfo{
+					ClusterID:              "abc",
+					NumWorkers:             1,
+					SparkVersion:           "7.1-scala12",
+					NodeTypeID:             "i3.xlarge",
+					AutoterminationMinutes: 60,
+					State:                  ClusterStateTerminated,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				Response: EventsResponse{
+					Events:     []ClusterEvent{},
+					TotalCount: 0,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: libraries.ClusterLibraryStatuses{
+					LibraryStatuses: []libraries.LibraryStatus{},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `
+		spark_version = "7.1-scala12"
+		node_type_id = "i3.xlarge"
+		num_workers = 1
+		state = "TERMINATED"`,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "TERMINATED", d.Get("state"))
+}
+
+func TestResourceClusterRead_ConvergingState(t *testing.T) {
+	for actual, expected := range map[ClusterState]string{
+		ClusterStateResizing:    "RUNNING",
+		ClusterStatePending:     "RUNNING",
+		ClusterStateTerminated:  "TERMINATED",
+		ClusterStateTerminating: "TERMINATING",
+	} {
+		d, err := qa.ResourceFixture{
+			Fixtures: []qa.HTTPFixture{
+				{
+					Method:   "GET",
+					Resource: "/api/2.0/clusters/get?cluster_id=abc",
+					Response: ClusterInfo{
+						ClusterID:    "abc",
+						SparkVersion: "7.1-scala12",
+						State:        actual,
+					},
+				},
+				{
+					Method:   "POST",
+					Resource: "/api/2.0/clusters/events",
+				},
+				{
+					Method:       "GET",
+					Resource:     "/api/2.0/libraries/cluster-status?cluster_id=abc",
+					ReuseRequest: true,
+					Response: libraries.ClusterLibraryStatuses{
+						LibraryStatuses: []libraries.LibraryStatus{},
+					},
+				},
+			},
+			Resource: ResourceCluster(),
+			Read:     true,
+			ID:       "abc",
+			InstanceState: map[string]string{
+				"spark_version": "7.1-scala12",
+				"state":         "RUNNING",
+			},
+		}.Apply(t)
+		require.NoError(t, err, err)
+		assert.Equal(t, expected, d.Get("state"), "actual state %s", actual)
+	}
+}
+
+func TestResourceClusterUpdate_StartTerminatedCluster(t *testing.T) {
+	terminated := qa.HTTPFixture{
+		Method:   "GET",
+		Resource: "/api/2.0/clusters/get?cluster_id=abc",
+		Response: ClusterInfo{
+			ClusterID:              "abc",
+			NumWorkers:             1,
+			SparkVersion:           "7.1-scala12",
+			NodeTypeID:             "i3.xlarge",
+			AutoterminationMinutes: 60,
+			State:                  ClusterStateTerminated,
+		},
+	}
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			terminated, // cluster config is not changed
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: libraries.ClusterLibraryStatuses{
+					LibraryStatuses: []libraries.LibraryStatus{},
+				},
+			},
+			terminated, // desired state check
+			terminated, // StartAndGetInfo
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/start",
+				ExpectedRequest: ClusterID{
+					ClusterID: "abc",
+				},
+			},
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+				ReuseRequest: true,
+				Response: ClusterInfo{
+					ClusterID:              "abc",
+					NumWorkers:             1,
+					SparkVersion:           "7.1-scala12",
+					NodeTypeID:             "i3.xlarge",
+					AutoterminationMinutes: 60,
+					State:                  ClusterStateRunning,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+			},
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				ReuseRequest: true,
+				Response: libraries.ClusterLibraryStatuses{
+					LibraryStatuses: []libraries.LibraryStatus{},
+				},
+			},
+		},
+		Resource: ResourceCluster(),
+		Update:   true,
+		ID:       "abc",
+		InstanceState: map[string]string{
+			"autotermination_minutes": "60",
+			"cluster_id":              "abc",
+			"num_workers":             "1",
+			"spark_version":           "7.1-scala12",
+			"node_type_id":            "i3.xlarge",
+			"state":                   "TERMINATED",
+		},
+		HCL: `
+		spark_version = "7.1-scala12"
+		node_type_id = "i3.xlarge"
+		num_workers = 1
+		state = "RUNNING"`,
+	}.ApplyNoError(t)
+}
+
+func TestResourceClusterCreate_WithLibraryUninstallPolicy(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/create",
+				Response: ClusterInfo{
+					ClusterID: "abc",
+					State:     ClusterStateRunning,
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+				Response: ClusterInfo{
+					ClusterID:              "abc",
+					NumWorkers:             100,
+					SparkVersion:           "7.1-scala12",
+					NodeTypeID:             "i3.xlarge",
+					AutoterminationMinutes: 60,
+					State:                  ClusterStateRunning,
+				},
+			},
+			{
+				Method:       "POST",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/events",
+				Response: EventsResponse{
+					Events: []ClusterEvent{},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/libraries/install",
+				ExpectedRequest: libraries.ClusterLibraryList{
+					ClusterID: "abc",
+					Libraries: []libraries.Library{
+						{
+							Jar: "foo.bar",
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: libraries.ClusterLibraryStatuses{
+					ClusterID: "abc",
+					LibraryStatuses: []libraries.LibraryStatus{
+						{
+							Status:   "FAILED",
+							Messages: []string{"fails for the test"},
+							Library: &libraries.Library{
+								Jar: "foo.bar",
+							},
+						},
+					},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/libraries/uninstall",
+				ExpectedRequest: libraries.ClusterLibraryList{
+					ClusterID: "abc",
+					Libraries: []libraries.Library{
+						{
+							Jar: "foo.bar",
+						},
+					},
+				},
+			},
+			{
+				// library is removed from the cluster
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: libraries.ClusterLibraryStatuses{
+					ClusterID: "abc",
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `num_workers = 100
+		spark_version = "7.1-scala12"
+		node_type_id = "i3.xlarge"
+
+		library {
+			jar = "foo.bar"
+			on_failure = "uninstall"
+		}`,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	// library is kept in the state, so that it's not installed again on the next apply
+	assert.Equal(t, 1, d.Get("library.#"))
+	assert.Equal(t, "jar:foo.bar", d.Get("library_status.0.library"))
+	assert.Equal(t, "FAILED", d.Get("library_status.0.status"))
+	assert.Equal(t, "fails for the test", d.Get("library_status.0.messages.0"))
+}
+
+func TestUninstalledFailures(t *testing.T) {
+	d := ResourceCluster().TestResourceData()
+	err := d.Set("library", []interface{}{
+		map[string]interface{}{"jar": "a.jar", "on_failure": "uninstall"},
+		map[string]interface{}{"jar": "b.jar", "on_failure": "uninstall"},
+		map[string]interface{}{"jar": "c.jar", "on_failure": "fail"},
+	})
+	require.NoError(t, err)
+	err = d.Set("library_status", []interface{}{
+		map[string]interface{}{"library": "jar:a.jar", "status": "FAILED",
+			"messages": []interface{}{"not found"}},
+		map[string]interface{}{"library": "jar:b.jar", "status": "INSTALLED"},
+		map[string]interface{}{"library": "jar:c.jar", "status": "FAILED"},
+	})
+	require.NoError(t, err)
+
+	libs := withoutUninstalledFailures(d, []libraries.Library{
+		{Jar: "a.jar"}, {Jar: "b.jar"}, {Jar: "c.jar"},
+	})
+	assert.Equal(t, []libraries.Library{{Jar: "b.jar"}, {Jar: "c.jar"}}, libs)
+
+	statuses := withUninstalledFailures(d, []libraries.LibraryStatus{
+		{Library: &libraries.Library{Jar: "a.jar"}, Status: "UNINSTALL_ON_RESTART"},
+		{Library: &libraries.Library{Jar: "b.jar"}, Status: "INSTALLED"},
+	}, libraryFailurePolicies(d))
+	require.Len(t, statuses, 2)
+	assert.Equal(t, "INSTALLED", statuses[0].Status)
+	assert.Equal(t, "jar:a.jar", statuses[1].Library.String())
+	assert.Equal(t, "FAILED", statuses[1].Status)
+	assert.Equal(t, []string{"not found"}, statuses[1].Messages)
+}
@@ -34,8 +34,9 @@ type CommandsAPI struct {
 	context context.Context
 }
 
-// Execute creates a spark context and executes a command and then closes context
-// Any leading whitespace is trimmed
+// Execute runs a command in an execution context, that is reused by later commands
+// of the same resource type on the same cluster and language, so that variables and
+// imports of the command are visible to the next ones. Any leading whitespace is trimmed
 func (a CommandsAPI) Execute(clusterID, language, commandStr string) common.CommandResults {
 	// this is the place, where API version propagation through context looks strange
 	ctx := context.WithValue(a.context, common.Api, common.API_2_0)
@@ -54,57 +55,86 @@ func (a CommandsAPI) Execute(clusterID, language, commandStr string) common.Comm
 	}
 	commandStr = internal.TrimLeadingWhitespace(commandStr)
 	log.Printf("[INFO] Executing %s command on %s:\n%s", language, clusterID, commandStr)
-	context, err := a.createContext(language, clusterID)
+	pool := contextPoolFor(a.client)
+	key := newContextKey(a.context, clusterID, language)
+	contextID, err := pool.acquire(a.context, key)
 	if err != nil {
 		return common.CommandResults{
 			ResultType: "error",
 			Summary:    err.Error(),
 		}
 	}
-	err = a.waitForContextReady(context, clusterID)
+	command, contextID, err := a.executeInContext(contextID, clusterID, language, commandStr)
 	if err != nil {
+		if contextID != "" {
+			a.destroyContext(contextID, clusterID)
+		}
+		pool.release(key, "")
 		return common.CommandResults{
 			ResultType: "error",
 			Summary:    err.Error(),
 		}
 	}
-	commandID, err := a.createCommand(context, clusterID, language, commandStr)
-	if err != nil {
+	pool.release(key, contextID)
+	if command.Results == nil {
+		log.Printf("[ERROR] Command has no results: %#v", command)
 		return common.CommandResults{
 			ResultType: "error",
-			Summary:    err.Error(),
+			Summary:    "Command has no results",
 		}
 	}
-	// TODO: merge getCommand and waitForCommandFinished to "waitForCommandResults"
-	err = a.waitForCommandFinished(commandID, context, clusterID)
-	if err != nil {
-		return common.CommandResults{
-			ResultType: "error",
-			Summary:    err.Error(),
+	return *command.Results
+}
+
+// executeInContext runs command in the idle context or in the new one, if idle context is empty
+// or is no longer usable, e.g. because of cluster restart. Returns context, where command ran.
+func (a CommandsAPI) executeInContext(contextID, clusterID, language, commandStr string) (Command, string, error) {
+	var command Command
+	reused := contextID != ""
+	if !reused {
+		var err error
+		contextID, err = a.newContext(language, clusterID)
+		if err != nil {
+			return command, contextID, err
 		}
 	}
-	command, err := a.getCommand(commandID, context, clusterID)
-	if err != nil {
-		return common.CommandResults{
-			ResultType: "error",
-			Summary:    err.Error(),
+	commandID, err := a.createCommand(contextID, clusterID, language, commandStr)
+	if err != nil && reused {
+		log.Printf("[DEBUG] Execution context %s is no longer usable: %s", contextID, err)
+		a.destroyContext(contextID, clusterID)
+		contextID, err = a.newContext(language, clusterID)
+		if err != nil {
+			return command, contextID, err
 		}
+		commandID, err = a.createCommand(contextID, clusterID, language, commandStr)
 	}
-	err = a.deleteContext(context, clusterID)
 	if err != nil {
-		return common.CommandResults{
-			ResultType: "error",
-			Summary:    err.Error(),
-		}
+		return command, contextID, err
 	}
-	if command.Results == nil {
-		log.Printf("[ERROR] Command has no results: %#v", command)
-		return common.CommandResults{
-			ResultType: "error",
-			Summary:    "Command has no results",
-		}
+	// TODO: merge getCommand and waitForCommandFinished to "waitForCommandResults"
+	err = a.waitForCommandFinished(commandID, contextID, clusterID)
+	if err != nil {
+		return command, contextID, err
+	}
+	command, err = a.getCommand(commandID, contextID, clusterID)
+	return command, contextID, err
+}
+
+// newContext creates execution context and waits until it's ready.
+// Returns context ID even on failure, so that it could be destroyed.
+func (a CommandsAPI) newContext(language, clusterID string) (string, error) {
+	contextID, err := a.createContext(language, clusterID)
+	if err != nil {
+		return "", err
+	}
+	return contextID, a.waitForContextReady(contextID, clusterID)
+}
+
+// destroyContext removes unusable context on the best effort basis
+func (a CommandsAPI) destroyContext(contextID, clusterID string) {
+	if err := a.deleteContext(contextID, clusterID); err != nil {
+		log.Printf("[WARN] Cannot destroy execution context %s on %s: %s", contextID, clusterID, err)
 	}
-	return *command.Results
 }
 
 type genericCommandRequest struct {
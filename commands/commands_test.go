@@ -3,6 +3,7 @@ package commands
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/databrickslabs/terraform-provider-databricks/clusters"
 	"github.com/databrickslabs/terraform-provider-databricks/common"
@@ -269,6 +270,14 @@ func TestCommandsAPIExecute_FailToWaitForContext(t *testing.T) {
 				Message: "Does not compute",
 			},
 		},
+		{
+			Method:   "POST",
+			Resource: "/api/1.2/contexts/destroy",
+			ExpectedRequest: genericCommandRequest{
+				ClusterID: "abc",
+				ContextID: "abc",
+			},
+		},
 	}, func(ctx context.Context, client *common.DatabricksClient) {
 		commands := NewCommandsAPI(ctx, client)
 		cr := commands.Execute("abc", "cobol", "Hello?")
@@ -307,6 +316,14 @@ func TestCommandsAPIExecute_FailToCreateCommand(t *testing.T) {
 				Message: "Does not compute",
 			},
 		},
+		{
+			Method:   "POST",
+			Resource: "/api/1.2/contexts/destroy",
+			ExpectedRequest: genericCommandRequest{
+				ClusterID: "abc",
+				ContextID: "abc",
+			},
+		},
 	}, func(ctx context.Context, client *common.DatabricksClient) {
 		commands := NewCommandsAPI(ctx, client)
 		cr := commands.Execute("abc", "cobol", "Hello?")
@@ -352,6 +369,14 @@ func TestCommandsAPIExecute_FailToWaitForCommand(t *testing.T) {
 				Message: "Does not compute",
 			},
 		},
+		{
+			Method:   "POST",
+			Resource: "/api/1.2/contexts/destroy",
+			ExpectedRequest: genericCommandRequest{
+				ClusterID: "abc",
+				ContextID: "abc",
+			},
+		},
 	}, func(ctx context.Context, client *common.DatabricksClient) {
 		commands := NewCommandsAPI(ctx, client)
 		cr := commands.Execute("abc", "cobol", "Hello?")
@@ -404,6 +429,14 @@ func TestCommandsAPIExecute_FailToGetCommand(t *testing.T) {
 				Message: "Does not compute",
 			},
 		},
+		{
+			Method:   "POST",
+			Resource: "/api/1.2/contexts/destroy",
+			ExpectedRequest: genericCommandRequest{
+				ClusterID: "abc",
+				ContextID: "abc",
+			},
+		},
 	}, func(ctx context.Context, client *common.DatabricksClient) {
 		commands := NewCommandsAPI(ctx, client)
 		cr := commands.Execute("abc", "cobol", "Hello?")
@@ -411,25 +444,144 @@ func TestCommandsAPIExecute_FailToGetCommand(t *testing.T) {
 	})
 }
 
-func TestCommandsAPIExecute_FailToDeleteContext(t *testing.T) {
+func TestCommandsAPIExecute_ReusesContext(t *testing.T) {
 	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+			Response: clusters.ClusterInfo{
+				State: "RUNNING",
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/1.2/contexts/create",
+			Response: Command{
+				ID: "ctx",
+			},
+		},
 		{
 			Method:   "GET",
-			Resource: "/api/2.0/clusters/get?cluster_id=abc",
+			Resource: "/api/1.2/contexts/status?clusterId=abc&contextId=ctx",
+			Response: Command{
+				Status: "Running",
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/1.2/commands/execute",
+			ExpectedRequest: genericCommandRequest{
+				Language:  "python",
+				ClusterID: "abc",
+				ContextID: "ctx",
+				Command:   "a = 1\n",
+			},
+			Response: Command{
+				ID: "first",
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/1.2/commands/execute",
+			ExpectedRequest: genericCommandRequest{
+				Language:  "python",
+				ClusterID: "abc",
+				ContextID: "ctx",
+				Command:   "print(a)\n",
+			},
+			Response: Command{
+				ID: "second",
+			},
+		},
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/1.2/commands/status?clusterId=abc&commandId=first&contextId=ctx",
+			Response: Command{
+				Status: "Finished",
+				Results: &common.CommandResults{
+					ResultType: "text",
+				},
+			},
+		},
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/1.2/commands/status?clusterId=abc&commandId=second&contextId=ctx",
+			Response: Command{
+				Status: "Finished",
+				Results: &common.CommandResults{
+					ResultType: "text",
+					Data:       "1",
+				},
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/1.2/contexts/destroy",
+			ExpectedRequest: genericCommandRequest{
+				ClusterID: "abc",
+				ContextID: "ctx",
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		commands := NewCommandsAPI(ctx, client)
+		cr := commands.Execute("abc", "python", "a = 1")
+		assert.NoError(t, cr.Err())
+		cr = commands.Execute("abc", "python", "print(a)")
+		assert.NoError(t, cr.Err())
+		assert.Equal(t, "1", cr.Text())
+		assert.Equal(t, map[contextKey][]string{
+			{"abc", "python", "unknown"}: {"ctx"},
+		}, contextPoolFor(client).idle)
+
+		pool := contextPoolFor(client)
+		pool.destroyIdle(commands)
+		assert.Len(t, pool.idle, 0)
+	})
+}
+
+func TestCommandsAPIExecute_StaleContext(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/clusters/get?cluster_id=abc",
 			Response: clusters.ClusterInfo{
 				State: "RUNNING",
 			},
 		},
+		{
+			Method:   "POST",
+			Resource: "/api/1.2/commands/execute",
+			Status:   400,
+			Response: common.APIError{
+				Message: "ContextNotFound",
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/1.2/contexts/destroy",
+			ExpectedRequest: genericCommandRequest{
+				ClusterID: "abc",
+				ContextID: "stale",
+			},
+			Status: 400,
+			Response: common.APIError{
+				Message: "ContextNotFound",
+			},
+		},
 		{
 			Method:   "POST",
 			Resource: "/api/1.2/contexts/create",
 			Response: Command{
-				ID: "abc",
+				ID: "fresh",
 			},
 		},
 		{
 			Method:   "GET",
-			Resource: "/api/1.2/contexts/status?clusterId=abc&contextId=abc",
+			Resource: "/api/1.2/contexts/status?clusterId=abc&contextId=fresh",
 			Response: Command{
 				Status: "Running",
 			},
@@ -437,18 +589,41 @@ func TestCommandsAPIExecute_FailToDeleteContext(t *testing.T) {
 		{
 			Method:   "POST",
 			Resource: "/api/1.2/commands/execute",
+			ExpectedRequest: genericCommandRequest{
+				Language:  "python",
+				ClusterID: "abc",
+				ContextID: "fresh",
+				Command:   "print(1)\n",
+			},
 			Response: Command{
-				ID: "abc",
+				ID: "cmd",
 			},
 		},
 		{
 			Method:       "GET",
 			ReuseRequest: true,
-			Resource:     "/api/1.2/commands/status?clusterId=abc&commandId=abc&contextId=abc",
+			Resource:     "/api/1.2/commands/status?clusterId=abc&commandId=cmd&contextId=fresh",
 			Response: Command{
 				Status: "Finished",
+				Results: &common.CommandResults{
+					ResultType: "text",
+					Data:       "1",
+				},
 			},
 		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		pool := contextPoolFor(client)
+		pool.idle[contextKey{"abc", "python", "unknown"}] = []string{"stale"}
+		commands := NewCommandsAPI(ctx, client)
+		cr := commands.Execute("abc", "python", "print(1)")
+		assert.NoError(t, cr.Err())
+		assert.Equal(t, "1", cr.Text())
+		assert.Equal(t, []string{"fresh"}, pool.idle[contextKey{"abc", "python", "unknown"}])
+	})
+}
+
+func TestDestroyContexts_Error(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
 		{
 			Method:   "POST",
 			Resource: "/api/1.2/contexts/destroy",
@@ -458,9 +633,90 @@ func TestCommandsAPIExecute_FailToDeleteContext(t *testing.T) {
 			},
 		},
 	}, func(ctx context.Context, client *common.DatabricksClient) {
-		commands := NewCommandsAPI(ctx, client)
-		cr := commands.Execute("abc", "cobol", "Hello?")
-		assert.EqualError(t, cr.Err(), "Does not compute")
+		pool := contextPoolFor(client)
+		pool.idle[contextKey{"abc", "python", "unknown"}] = []string{"abc"}
+		pool.destroyIdle(NewCommandsAPI(ctx, client))
+		assert.Len(t, pool.idle, 0)
+	})
+}
+
+func TestContextPool_Limit(t *testing.T) {
+	pool := contextPoolFor(&common.DatabricksClient{})
+	key := contextKey{"abc", "python", "unknown"}
+	for i := 0; i < maxContextsPerCluster; i++ {
+		_, err := pool.acquire(context.Background(), key)
+		assert.NoError(t, err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := pool.acquire(ctx, key)
+	assert.EqualError(t, err, "context canceled")
+
+	pool.release(key, "ctx")
+	contextID, err := pool.acquire(context.Background(), key)
+	assert.NoError(t, err)
+	assert.Equal(t, "ctx", contextID)
+}
+
+func TestContextPool_ReusedAcrossOperations(t *testing.T) {
+	pool := contextPoolFor(&common.DatabricksClient{})
+	mount := context.WithValue(context.Background(), common.ResourceName, "mount")
+	operation, finish := context.WithCancel(mount)
+	key := newContextKey(operation, "abc", "python")
+	_, err := pool.acquire(operation, key)
+	require.NoError(t, err)
+	pool.release(key, "ctx")
+	finish()
+
+	// other resource types and data sources don't share the state
+	for _, ctx := range []context.Context{
+		context.WithValue(context.Background(), common.ResourceName, "command"),
+		context.WithValue(mount, common.IsData, "yes"),
+	} {
+		other := newContextKey(ctx, "abc", "python")
+		contextID, err := pool.acquire(ctx, other)
+		require.NoError(t, err)
+		assert.Equal(t, "", contextID)
+		pool.release(other, "")
+	}
+
+	next := newContextKey(mount, "abc", "python")
+	assert.Equal(t, key, next)
+	contextID, err := pool.acquire(mount, next)
+	require.NoError(t, err)
+	assert.Equal(t, "ctx", contextID)
+}
+
+func TestContextPool_IdleTimeout(t *testing.T) {
+	defer func(timeout time.Duration) {
+		contextIdleTimeout = timeout
+	}(contextIdleTimeout)
+	contextIdleTimeout = 10 * time.Millisecond
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "POST",
+			Resource: "/api/1.2/contexts/destroy",
+			ExpectedRequest: genericCommandRequest{
+				ClusterID: "abc",
+				ContextID: "ctx",
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		pool := contextPoolFor(client)
+		key := contextKey{"abc", "python", "unknown"}
+		_, err := pool.acquire(ctx, key)
+		require.NoError(t, err)
+		pool.release(key, "ctx")
+		// reused context is destroyed only once, after its latest release
+		contextID, err := pool.acquire(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, "ctx", contextID)
+		pool.release(key, "ctx")
+		assert.Eventually(t, func() bool {
+			pool.mu.Lock()
+			defer pool.mu.Unlock()
+			return len(pool.idle) == 0
+		}, time.Second, 10*time.Millisecond)
 	})
 }
 
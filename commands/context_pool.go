@@ -0,0 +1,174 @@
+package commands
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+)
+
+// maxContextsPerCluster limits concurrently used execution contexts for every cluster and language,
+// as Databricks allows only 150 contexts per cluster, that are shared with notebooks
+const maxContextsPerCluster = 4
+
+// contextIdleTimeout is the time, after which idle execution context is destroyed,
+// unless it's reused by another command of the same resource type
+var contextIdleTimeout = 1 * time.Minute
+
+type contextKey struct {
+	clusterID string
+	language  string
+	resource  string
+}
+
+// newContextKey isolates contexts of different resource types and data sources,
+// so that state of commands, like Python variables, is shared only by commands
+// of the same resource type
+func newContextKey(ctx context.Context, clusterID, language string) contextKey {
+	resource := common.ResourceName.GetOrUnknown(ctx)
+	if common.IsData.GetOrUnknown(ctx) == "yes" {
+		resource = "data." + resource
+	}
+	return contextKey{clusterID, language, resource}
+}
+
+// contextPool reuses execution contexts across commands within the same provider run,
+// including commands of different resources of the same type. Commands, that run in the
+// same context, share its state, like Python variables.
+type contextPool struct {
+	client *common.DatabricksClient
+	mu     sync.Mutex
+	idle   map[contextKey][]string
+	slots  map[contextKey]chan struct{}
+	// sequence number of the latest release of every idle context
+	released map[string]uint64
+	sequence uint64
+}
+
+var contextPools = struct {
+	sync.Mutex
+	clients map[*common.DatabricksClient]*contextPool
+}{clients: map[*common.DatabricksClient]*contextPool{}}
+
+// contextPoolFor returns execution contexts pool of the client
+func contextPoolFor(client *common.DatabricksClient) *contextPool {
+	contextPools.Lock()
+	defer contextPools.Unlock()
+	pool, ok := contextPools.clients[client]
+	if !ok {
+		pool = &contextPool{
+			client:   client,
+			idle:     map[contextKey][]string{},
+			slots:    map[contextKey]chan struct{}{},
+			released: map[string]uint64{},
+		}
+		contextPools.clients[client] = pool
+	}
+	return pool
+}
+
+// acquire waits until there are less than maxContextsPerCluster contexts in use and returns
+// an idle context, if there's any. Empty context ID means that the new one has to be created.
+func (p *contextPool) acquire(ctx context.Context, key contextKey) (string, error) {
+	p.mu.Lock()
+	slots, ok := p.slots[key]
+	if !ok {
+		slots = make(chan struct{}, maxContextsPerCluster)
+		p.slots[key] = slots
+	}
+	p.mu.Unlock()
+	select {
+	case slots <- struct{}{}:
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	idle := p.idle[key]
+	if len(idle) == 0 {
+		return "", nil
+	}
+	contextID := idle[len(idle)-1]
+	p.idle[key] = idle[:len(idle)-1]
+	return contextID, nil
+}
+
+// release frees the slot and keeps the context for the next command, unless its ID is empty.
+// Idle context is destroyed after contextIdleTimeout or once provider is done.
+func (p *contextPool) release(key contextKey, contextID string) {
+	p.mu.Lock()
+	if contextID != "" {
+		p.idle[key] = append(p.idle[key], contextID)
+		p.sequence++
+		p.released[contextID] = p.sequence
+		go p.evictLater(key, contextID, p.sequence, contextIdleTimeout)
+	}
+	slots := p.slots[key]
+	p.mu.Unlock()
+	<-slots
+}
+
+// evictLater destroys the context, unless it was reused and released again in the meantime
+func (p *contextPool) evictLater(key contextKey, contextID string, sequence uint64, timeout time.Duration) {
+	time.Sleep(timeout)
+	p.mu.Lock()
+	if p.released[contextID] != sequence || !p.removeIdle(key, contextID) {
+		p.mu.Unlock()
+		return
+	}
+	p.mu.Unlock()
+	// context of the operation, that released it, may be already finished
+	NewCommandsAPI(context.Background(), p.client).destroyContext(contextID, key.clusterID)
+}
+
+// removeIdle removes context from idle ones and returns true, if it was idle
+func (p *contextPool) removeIdle(key contextKey, contextID string) bool {
+	idle := p.idle[key]
+	for i, id := range idle {
+		if id != contextID {
+			continue
+		}
+		p.idle[key] = append(idle[:i:i], idle[i+1:]...)
+		if len(p.idle[key]) == 0 {
+			delete(p.idle, key)
+		}
+		delete(p.released, contextID)
+		return true
+	}
+	return false
+}
+
+// destroyIdle destroys all idle contexts of the pool
+func (p *contextPool) destroyIdle(a CommandsAPI) {
+	p.mu.Lock()
+	idle := p.idle
+	p.idle = map[contextKey][]string{}
+	p.released = map[string]uint64{}
+	p.mu.Unlock()
+	var wg sync.WaitGroup
+	for key, contextIDs := range idle {
+		for _, contextID := range contextIDs {
+			wg.Add(1)
+			go func(contextID, clusterID string) {
+				defer wg.Done()
+				a.destroyContext(contextID, clusterID)
+			}(contextID, key.clusterID)
+		}
+	}
+	wg.Wait()
+}
+
+// DestroyContexts destroys idle execution contexts of all clients, that are not yet evicted.
+// It's called once provider or exporter is done. Terraform kills provider process shortly
+// after it stops serving, so contexts, that are not destroyed by the deadline of ctx, are
+// left on the cluster until it's restarted or evicts them.
+func DestroyContexts(ctx context.Context) {
+	contextPools.Lock()
+	pools := contextPools.clients
+	contextPools.clients = map[*common.DatabricksClient]*contextPool{}
+	contextPools.Unlock()
+	for client, pool := range pools {
+		pool.destroyIdle(NewCommandsAPI(ctx, client))
+	}
+}
@@ -0,0 +1,207 @@
+package commands
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/databrickslabs/terraform-provider-databricks/clusters"
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ANSI colors from Python tracebacks
+var ansiRE = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// runCommand starts the cluster, if it's not running, and executes the command on it
+func runCommand(ctx context.Context, c *common.DatabricksClient,
+	clusterID, language, command string) (common.CommandResults, error) {
+	if _, err := clusters.NewClustersAPI(ctx, c).StartAndGetInfo(clusterID); err != nil {
+		return common.CommandResults{}, err
+	}
+	cr := c.CommandExecutor(ctx).Execute(clusterID, language, command)
+	if !cr.Failed() {
+		return cr, nil
+	}
+	cause := strings.TrimSpace(ansiRE.ReplaceAllLiteralString(cr.Cause, ""))
+	if cause == "" || strings.Contains(cr.Error(), cause) {
+		return cr, cr.Err()
+	}
+	return cr, fmt.Errorf("%s\n%s", cr.Error(), cause)
+}
+
+// cellString formats table cell as string attribute
+func cellString(v interface{}) string {
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return x
+	case int64, float64, bool:
+		return fmt.Sprint(x)
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(raw)
+}
+
+// setCommandResults sets text or table results of the command as attributes
+func setCommandResults(cr common.CommandResults, d *schema.ResourceData) error {
+	if cr.Truncated {
+		log.Printf("[WARN] Results of the command are truncated")
+	}
+	columns := cr.Columns()
+	if rows, ok := cr.Data.([]interface{}); ok && len(columns) == 0 && len(rows) > 0 {
+		// results without schema have default Spark column names
+		if first, ok := rows[0].([]interface{}); ok {
+			for i := range first {
+				columns = append(columns, fmt.Sprintf("_c%d", i))
+			}
+		}
+	}
+	rows := []map[string]string{}
+	values := make([]interface{}, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	for cr.Scan(dest...) {
+		row := map[string]string{}
+		for i, column := range columns {
+			row[column] = cellString(values[i])
+		}
+		rows = append(rows, row)
+	}
+	if err := cr.Err(); err != nil {
+		return err
+	}
+	for k, v := range map[string]interface{}{
+		"result_type": cr.ResultType,
+		"text":        cr.Text(),
+		"columns":     columns,
+		"rows":        rows,
+	} {
+		if err := d.Set(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func commandID(clusterID, command string) string {
+	return fmt.Sprintf("%s/%x", clusterID, md5.Sum([]byte(command)))
+}
+
+// commandSchema has arguments to run a command and attributes with its results
+func commandSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"cluster_id": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"language": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "python",
+			ValidateFunc: validation.StringInSlice([]string{"python", "scala", "sql", "r"}, false),
+		},
+		"command": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"result_type": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"text": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"columns": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"rows": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// ResourceCommand runs a command on the cluster during creation and an optional
+// destroy_command, when resource is destroyed
+func ResourceCommand() *schema.Resource {
+	s := commandSchema()
+	s["destroy_command"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+	}
+	for _, k := range []string{"cluster_id", "language", "command"} {
+		s[k].ForceNew = true
+	}
+	return common.Resource{
+		Schema: s,
+		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			clusterID := d.Get("cluster_id").(string)
+			command := d.Get("command").(string)
+			cr, err := runCommand(ctx, c, clusterID, d.Get("language").(string), command)
+			if err != nil {
+				return err
+			}
+			d.SetId(commandID(clusterID, command))
+			return setCommandResults(cr, d)
+		},
+		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			// results are kept in the state, as command is not executed again
+			return nil
+		},
+		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			// only destroy_command could be changed, and it's used from the state
+			return nil
+		},
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			command := d.Get("destroy_command").(string)
+			if command == "" {
+				return nil
+			}
+			clusterID := d.Get("cluster_id").(string)
+			_, err := runCommand(ctx, c, clusterID, d.Get("language").(string), command)
+			if common.IsMissing(err) {
+				log.Printf("[WARN] Cluster %s is removed, skipping destroy command", clusterID)
+				return nil
+			}
+			return err
+		},
+	}.ToResource()
+}
+
+// DataSourceCommand runs a command on the cluster during every refresh
+func DataSourceCommand() *schema.Resource {
+	return &schema.Resource{
+		Schema: commandSchema(),
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			clusterID := d.Get("cluster_id").(string)
+			command := d.Get("command").(string)
+			cr, err := runCommand(ctx, m.(*common.DatabricksClient), clusterID,
+				d.Get("language").(string), command)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId(commandID(clusterID, command))
+			return diag.FromErr(setCommandResults(cr, d))
+		},
+	}
+}
@@ -0,0 +1,171 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/clusters"
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var runningCluster = qa.HTTPFixture{
+	Method:       "GET",
+	ReuseRequest: true,
+	Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+	Response: clusters.ClusterInfo{
+		ClusterID: "abc",
+		State:     clusters.ClusterStateRunning,
+	},
+}
+
+func TestResourceCommandCreate(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{runningCluster},
+		Resource: ResourceCommand(),
+		CommandMock: func(commandStr string) common.CommandResults {
+			assert.Equal(t, "CREATE DATABASE IF NOT EXISTS foo", commandStr)
+			return common.CommandResults{
+				ResultType: "table",
+				Schema: []interface{}{
+					map[string]interface{}{"name": "name", "type": `"string"`},
+					map[string]interface{}{"name": "size", "type": `"long"`},
+				},
+				Data: []interface{}{
+					[]interface{}{"a", float64(1)},
+					[]interface{}{nil, float64(2)},
+				},
+			}
+		},
+		HCL: `
+		cluster_id = "abc"
+		language = "sql"
+		command = "CREATE DATABASE IF NOT EXISTS foo"
+		destroy_command = "DROP DATABASE foo"`,
+		Create: true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "abc/70474b4e4c505e6883a511da547317af", d.Id())
+	assert.Equal(t, "table", d.Get("result_type"))
+	assert.Equal(t, []interface{}{"name", "size"}, d.Get("columns"))
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "a", "size": "1"},
+		map[string]interface{}{"name": "", "size": "2"},
+	}, d.Get("rows"))
+}
+
+func TestResourceCommandCreate_Error(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{runningCluster},
+		Resource: ResourceCommand(),
+		CommandMock: func(commandStr string) common.CommandResults {
+			return common.CommandResults{
+				ResultType: "error",
+				Summary:    "<span class='ansi-red-fg'>NameError</span>: name 'x' is not defined",
+				Cause:      "\x1b[0;31mNameError\x1b[0m: name 'x' is not defined\n  at line 1",
+			}
+		},
+		HCL: `
+		cluster_id = "abc"
+		command = "print(x)"`,
+		Create: true,
+	}.ExpectError(t, "NameError: name 'x' is not defined\n"+
+		"NameError: name 'x' is not defined\n  at line 1")
+}
+
+func TestResourceCommandDelete(t *testing.T) {
+	called := false
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{runningCluster},
+		Resource: ResourceCommand(),
+		CommandMock: func(commandStr string) common.CommandResults {
+			assert.Equal(t, "spark.catalog.clearCache()", commandStr)
+			called = true
+			return common.CommandResults{
+				ResultType: "text",
+				Data:       "",
+			}
+		},
+		HCL: `
+		cluster_id = "abc"
+		command = "spark.table('foo').cache().count()"
+		destroy_command = "spark.catalog.clearCache()"`,
+		ID:     "abc/123",
+		Delete: true,
+	}.ApplyNoError(t)
+	assert.True(t, called)
+}
+
+func TestResourceCommandDelete_ClusterRemoved(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/get?cluster_id=abc",
+				Status:   400,
+				Response: common.APIErrorBody{
+					ErrorCode: "INVALID_PARAMETER_VALUE",
+					Message:   "Cluster abc does not exist",
+				},
+			},
+		},
+		Resource: ResourceCommand(),
+		HCL: `
+		cluster_id = "abc"
+		command = "spark.table('foo').cache().count()"
+		destroy_command = "spark.catalog.clearCache()"`,
+		ID:     "abc/123",
+		Delete: true,
+	}.ApplyNoError(t)
+}
+
+func TestDataSourceCommand(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{runningCluster},
+		CommandMock: func(commandStr string) common.CommandResults {
+			assert.Equal(t, "print(spark.version)", commandStr)
+			return common.CommandResults{
+				ResultType: "text",
+				Data:       "3.2.1",
+			}
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceCommand(),
+		ID:          ".",
+		HCL: `
+		cluster_id = "abc"
+		command = "print(spark.version)"`,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "3.2.1", d.Get("text"))
+	assert.Equal(t, "text", d.Get("result_type"))
+	assert.Equal(t, 0, d.Get("rows.#"))
+}
+
+func TestDataSourceCommand_NoSchema(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{runningCluster},
+		CommandMock: func(commandStr string) common.CommandResults {
+			return common.CommandResults{
+				ResultType: "table",
+				Data: []interface{}{
+					[]interface{}{true, map[string]interface{}{"a": "b"}},
+				},
+			}
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceCommand(),
+		ID:          ".",
+		HCL: `
+		cluster_id = "abc"
+		language = "scala"
+		command = "display(df)"`,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"_c0": "true", "_c1": `{"a":"b"}`},
+	}, d.Get("rows"))
+}
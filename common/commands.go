@@ -2,9 +2,13 @@ package common
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"html"
+	"math"
+	"reflect"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -68,6 +72,7 @@ type CommandResults struct {
 	Truncated    bool        `json:"truncated,omitempty"`
 	IsJSONSchema bool        `json:"isJsonSchema,omitempty"`
 	pos          int
+	scanErr      error
 }
 
 // Failed tells if command execution failed
@@ -83,10 +88,10 @@ func (cr *CommandResults) Text() string {
 	return outRE.ReplaceAllLiteralString(cr.Data.(string), "")
 }
 
-// Err returns error type
+// Err returns error of command execution or scanning of table results
 func (cr *CommandResults) Err() error {
 	if !cr.Failed() {
-		return nil
+		return cr.scanErr
 	}
 	return fmt.Errorf(cr.Error())
 }
@@ -119,29 +124,240 @@ func (cr *CommandResults) Error() string {
 	return summary
 }
 
-// Scan scans for results
+// tableColumn is a column of table results, with type from Spark schema
+type tableColumn struct {
+	Name string
+	Type string
+}
+
+// columns parses schema of table results, which is the same for Python, Scala, SQL and R.
+// Every type is JSON-encoded: either a quoted simple type name, like "\"long\"", or an object
+// for complex types, like struct, array or map.
+func (cr *CommandResults) columns() (columns []tableColumn) {
+	schema, ok := cr.Schema.([]interface{})
+	if !ok {
+		return
+	}
+	for _, v := range schema {
+		field, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		column := tableColumn{}
+		column.Name, _ = field["name"].(string)
+		switch t := field["type"].(type) {
+		case string:
+			var decoded interface{}
+			if err := json.Unmarshal([]byte(t), &decoded); err != nil {
+				column.Type = t
+				break
+			}
+			switch dt := decoded.(type) {
+			case string:
+				column.Type = dt
+			case map[string]interface{}:
+				column.Type, _ = dt["type"].(string)
+			}
+		case map[string]interface{}:
+			column.Type, _ = t["type"].(string)
+		}
+		columns = append(columns, column)
+	}
+	return
+}
+
+// Columns returns column names of table results
+func (cr *CommandResults) Columns() (names []string) {
+	for _, column := range cr.columns() {
+		names = append(names, column.Name)
+	}
+	return
+}
+
+// typedValue converts JSON-decoded cell to Go value of the column type: int64 for
+// integral types, float64 for fractional types, bool and string. Other types, like
+// timestamps or structs, are kept as they were decoded.
+func typedValue(v interface{}, columnType string) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	switch {
+	case columnType == "byte" || columnType == "short" ||
+		columnType == "integer" || columnType == "long":
+		switch x := v.(type) {
+		case float64:
+			if x != math.Trunc(x) {
+				return nil, fmt.Errorf("%v is not %s", x, columnType)
+			}
+			return int64(x), nil
+		case string:
+			return strconv.ParseInt(x, 10, 64)
+		}
+	case columnType == "float" || columnType == "double" ||
+		strings.HasPrefix(columnType, "decimal"):
+		if x, ok := v.(string); ok {
+			return strconv.ParseFloat(x, 64)
+		}
+	case columnType == "boolean":
+		if x, ok := v.(string); ok {
+			return strconv.ParseBool(x)
+		}
+	case columnType == "string":
+		if _, ok := v.(string); !ok {
+			return fmt.Sprint(v), nil
+		}
+	}
+	return v, nil
+}
+
+// assign sets value to the pointer, converting between numeric types
+func assign(dest interface{}, v interface{}) error {
+	dv := reflect.ValueOf(dest)
+	if dv.Kind() != reflect.Ptr || dv.IsNil() {
+		return fmt.Errorf("destination must be a non-nil pointer, got %T", dest)
+	}
+	target := dv.Elem()
+	if v == nil {
+		target.Set(reflect.Zero(target.Type()))
+		return nil
+	}
+	sv := reflect.ValueOf(v)
+	if sv.Type().AssignableTo(target.Type()) {
+		target.Set(sv)
+		return nil
+	}
+	switch target.Kind() {
+	case reflect.String:
+		target.SetString(fmt.Sprint(v))
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch sv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if !target.OverflowInt(sv.Int()) {
+				target.SetInt(sv.Int())
+				return nil
+			}
+		case reflect.Float32, reflect.Float64:
+			f := sv.Float()
+			if f == math.Trunc(f) && !target.OverflowInt(int64(f)) {
+				target.SetInt(int64(f))
+				return nil
+			}
+		}
+	case reflect.Float32, reflect.Float64:
+		switch sv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			target.SetFloat(float64(sv.Int()))
+			return nil
+		case reflect.Float32, reflect.Float64:
+			target.SetFloat(sv.Float())
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot scan %T into %s", v, target.Type())
+}
+
+// structFields returns pointers to fields of struct destination in the order of
+// columns. Columns are matched by json tag or field name, ignoring the case, and
+// by field order, when results have no schema. Fields with json tag must have
+// the matching column, while fields without it are left intact, if there's none.
+func structFields(dest interface{}, columns []tableColumn) ([]interface{}, bool, error) {
+	dv := reflect.ValueOf(dest)
+	if dv.Kind() != reflect.Ptr || dv.IsNil() || dv.Elem().Kind() != reflect.Struct {
+		return nil, false, nil
+	}
+	sv := dv.Elem()
+	st := sv.Type()
+	fields := map[string]interface{}{}
+	tagged := []string{}
+	ordered := []interface{}{}
+	for i := 0; i < st.NumField(); i++ {
+		field := st.Field(i)
+		if field.PkgPath != "" {
+			// unexported field
+			continue
+		}
+		name := field.Name
+		if tag := strings.Split(field.Tag.Get("json"), ",")[0]; tag == "-" {
+			continue
+		} else if tag != "" {
+			name = tag
+			tagged = append(tagged, tag)
+		}
+		ptr := sv.Field(i).Addr().Interface()
+		fields[strings.ToLower(name)] = ptr
+		ordered = append(ordered, ptr)
+	}
+	if len(columns) == 0 {
+		return ordered, true, nil
+	}
+	pointers := make([]interface{}, len(columns))
+	matched := map[string]bool{}
+	for i, column := range columns {
+		name := strings.ToLower(column.Name)
+		pointers[i] = fields[name]
+		matched[name] = true
+	}
+	for _, tag := range tagged {
+		if !matched[strings.ToLower(tag)] {
+			return nil, true, fmt.Errorf("no column for %s field of %s", tag, st.Name())
+		}
+	}
+	return pointers, true, nil
+}
+
+// Scan copies columns of the next row into destinations, returning false when there
+// are no more rows or scanning failed, which is reported by Err(). Destination is either
+// a single pointer to struct, where columns are mapped to fields by name, or a pointer
+// per column.
 func (cr *CommandResults) Scan(dest ...interface{}) bool {
-	if cr.ResultType != "table" {
+	if cr.ResultType != "table" || cr.scanErr != nil {
+		return false
+	}
+	rows, ok := cr.Data.([]interface{})
+	if !ok || cr.pos >= len(rows) {
 		return false
 	}
-	if rows, ok := cr.Data.([]interface{}); ok {
-		if cr.pos >= len(rows) {
+	cols, ok := rows[cr.pos].([]interface{})
+	if !ok {
+		cr.scanErr = fmt.Errorf("row %d is not a list: %v", cr.pos, rows[cr.pos])
+		return false
+	}
+	columns := cr.columns()
+	if len(dest) == 1 {
+		pointers, ok, err := structFields(dest[0], columns)
+		if err != nil {
+			cr.scanErr = err
 			return false
 		}
-		if cols, ok := rows[cr.pos].([]interface{}); ok {
-			for i := range dest {
-				switch d := dest[i].(type) {
-				case *string:
-					*d = cols[i].(string)
-				case *int:
-					*d = cols[i].(int)
-				case *bool:
-					*d = cols[i].(bool)
-				}
-			}
-			cr.pos++
-			return true
+		if ok {
+			dest = pointers
+		}
+	}
+	if len(dest) > len(cols) {
+		cr.scanErr = fmt.Errorf("expected %d columns, but got %d", len(dest), len(cols))
+		return false
+	}
+	for i := range dest {
+		if dest[i] == nil {
+			// column is not mapped to any field
+			continue
+		}
+		columnType := ""
+		columnName := fmt.Sprintf("#%d", i)
+		if i < len(columns) {
+			columnType = columns[i].Type
+			columnName = columns[i].Name
+		}
+		v, err := typedValue(cols[i], columnType)
+		if err == nil {
+			err = assign(dest[i], v)
+		}
+		if err != nil {
+			cr.scanErr = fmt.Errorf("column %s: %w", columnName, err)
+			return false
 		}
 	}
-	return false
+	cr.pos++
+	return true
 }
@@ -74,3 +74,151 @@ func TestCommandResults_Scan(t *testing.T) {
 
 	assert.False(t, cr.Scan(&a, &b, &c))
 }
+
+func TestCommandResults_ScanTyped(t *testing.T) {
+	cr := CommandResults{
+		ResultType: "table",
+		Schema: []interface{}{
+			map[string]interface{}{"name": "name", "type": `"string"`, "metadata": "{}"},
+			map[string]interface{}{"name": "size", "type": `"long"`, "metadata": "{}"},
+			map[string]interface{}{"name": "ratio", "type": `"decimal(10,2)"`, "metadata": "{}"},
+			map[string]interface{}{"name": "enabled", "type": `"boolean"`, "metadata": "{}"},
+			map[string]interface{}{"name": "tags", "type": `{"type":"map"}`, "metadata": "{}"},
+		},
+		Data: []interface{}{
+			[]interface{}{"foo", float64(1024), "0.5", "true", nil},
+		},
+	}
+	assert.Equal(t, []string{"name", "size", "ratio", "enabled", "tags"}, cr.Columns())
+	var name string
+	var size int
+	var ratio float64
+	var enabled bool
+	var tags interface{}
+	assert.True(t, cr.Scan(&name, &size, &ratio, &enabled, &tags))
+	assert.Equal(t, "foo", name)
+	assert.Equal(t, 1024, size)
+	assert.Equal(t, 0.5, ratio)
+	assert.Equal(t, true, enabled)
+	assert.Nil(t, tags)
+	assert.False(t, cr.Scan(&name, &size, &ratio, &enabled, &tags))
+	assert.NoError(t, cr.Err())
+}
+
+func TestCommandResults_ScanStruct(t *testing.T) {
+	type row struct {
+		Name    string
+		Size    int64  `json:"num_bytes"`
+		Ignored string `json:"-"`
+		Missing bool
+	}
+	cr := CommandResults{
+		ResultType: "table",
+		Schema: []interface{}{
+			map[string]interface{}{"name": "NUM_BYTES", "type": `"integer"`},
+			map[string]interface{}{"name": "comment", "type": `"string"`},
+			map[string]interface{}{"name": "name", "type": `"string"`},
+		},
+		Data: []interface{}{
+			[]interface{}{float64(1), "irrelevant", "a"},
+			[]interface{}{float64(2), "irrelevant", "b"},
+		},
+	}
+	var r row
+	var rows []row
+	for cr.Scan(&r) {
+		rows = append(rows, r)
+	}
+	assert.NoError(t, cr.Err())
+	assert.Equal(t, []row{{Name: "a", Size: 1}, {Name: "b", Size: 2}}, rows)
+
+	// without schema fields are matched by their order
+	cr = CommandResults{
+		ResultType: "table",
+		Data: []interface{}{
+			[]interface{}{"c", float64(3), true},
+		},
+	}
+	assert.True(t, cr.Scan(&r))
+	assert.Equal(t, row{Name: "c", Size: 3, Missing: true}, r)
+}
+
+func TestCommandResults_ScanErrors(t *testing.T) {
+	cr := CommandResults{
+		ResultType: "table",
+		Schema: []interface{}{
+			map[string]interface{}{"name": "size", "type": `"long"`},
+		},
+		Data: []interface{}{
+			[]interface{}{float64(1.5)},
+		},
+	}
+	var size int
+	assert.False(t, cr.Scan(&size))
+	assert.EqualError(t, cr.Err(), "column size: 1.5 is not long")
+
+	cr = CommandResults{
+		ResultType: "table",
+		Data: []interface{}{
+			[]interface{}{"foo"},
+		},
+	}
+	assert.False(t, cr.Scan(&size))
+	assert.EqualError(t, cr.Err(), "column #0: cannot scan string into int")
+
+	cr = CommandResults{
+		ResultType: "table",
+		Data: []interface{}{
+			[]interface{}{"foo"},
+		},
+	}
+	var a, b string
+	assert.False(t, cr.Scan(&a, &b))
+	assert.EqualError(t, cr.Err(), "expected 2 columns, but got 1")
+
+	cr = CommandResults{
+		ResultType: "table",
+		Data:       []interface{}{"foo"},
+	}
+	assert.False(t, cr.Scan(&a))
+	assert.EqualError(t, cr.Err(), "row 0 is not a list: foo")
+
+	type grant struct {
+		Principal  string `json:"principal"`
+		ActionType string `json:"action_type"`
+	}
+	cr = CommandResults{
+		ResultType: "table",
+		Schema: []interface{}{
+			map[string]interface{}{"name": "Principal", "type": `"string"`},
+			map[string]interface{}{"name": "ActionType", "type": `"string"`},
+		},
+		Data: []interface{}{
+			[]interface{}{"users", "SELECT"},
+		},
+	}
+	var g grant
+	assert.False(t, cr.Scan(&g))
+	assert.EqualError(t, cr.Err(), "no column for action_type field of grant")
+}
+
+func TestCommandResults_ScanStructCase(t *testing.T) {
+	type grant struct {
+		Principal  string `json:"principal"`
+		ActionType string
+	}
+	cr := CommandResults{
+		ResultType: "table",
+		Schema: []interface{}{
+			map[string]interface{}{"name": "PRINCIPAL", "type": `"string"`},
+			map[string]interface{}{"name": "actiontype", "type": `"string"`},
+		},
+		Data: []interface{}{
+			[]interface{}{"users", "SELECT"},
+		},
+	}
+	var g grant
+	assert.True(t, cr.Scan(&g))
+	assert.NoError(t, cr.Err())
+	assert.Equal(t, grant{Principal: "users", ActionType: "SELECT"}, g)
+}
@@ -74,6 +74,18 @@ func MustSchemaPath(s map[string]*schema.Schema, path ...string) *schema.Schema
 	return sch
 }
 
+// MarkComputed makes all nested fields computed, which is used by data sources,
+// that return entities with the same structure as resources
+func MarkComputed(s map[string]*schema.Schema) {
+	for _, v := range s {
+		v.Computed = true
+		v.Required = false
+		if nested, ok := v.Elem.(*schema.Resource); ok {
+			MarkComputed(nested.Schema)
+		}
+	}
+}
+
 // StructToSchema makes schema from a struct type & applies customizations from callback given
 func StructToSchema(v interface{}, customize func(map[string]*schema.Schema) map[string]*schema.Schema) map[string]*schema.Schema {
 	rv := reflect.ValueOf(v)
@@ -55,6 +55,14 @@ type PipelineTask struct {
 	PipelineID string `json:"pipeline_id"`
 }
 
+// ConditionTask contains the information for a task, that evaluates a condition and
+// lets dependent tasks run only on the matching outcome
+type ConditionTask struct {
+	Left  string `json:"left"`
+	Right string `json:"right"`
+	Op    string `json:"op"`
+}
+
 // EmailNotifications contains the information for email notifications after job completion
 type EmailNotifications struct {
 	OnStart               []string `json:"on_start,omitempty"`
@@ -63,6 +71,18 @@ type EmailNotifications struct {
 	NoAlertForSkippedRuns bool     `json:"no_alert_for_skipped_runs,omitempty"`
 }
 
+// Webhook contains a reference by id to one of the centrally configured notification destinations
+type Webhook struct {
+	ID string `json:"id"`
+}
+
+// WebhookNotifications contains the information for webhook notifications sent after job start or completion
+type WebhookNotifications struct {
+	OnStart   []Webhook `json:"on_start,omitempty"`
+	OnSuccess []Webhook `json:"on_success,omitempty"`
+	OnFailure []Webhook `json:"on_failure,omitempty"`
+}
+
 // CronSchedule contains the information for the quartz cron expression
 type CronSchedule struct {
 	QuartzCronExpression string `json:"quartz_cron_expression"`
@@ -70,29 +90,57 @@ type CronSchedule struct {
 	PauseStatus          string `json:"pause_status,omitempty" tf:"computed"`
 }
 
+// FileArrival contains the information for triggering a job, when new files arrive at a storage location
+type FileArrival struct {
+	URL                           string `json:"url"`
+	MinTimeBetweenTriggersSeconds int32  `json:"min_time_between_triggers_seconds,omitempty"`
+	WaitAfterLastChangeSeconds    int32  `json:"wait_after_last_change_seconds,omitempty"`
+}
+
+// Trigger contains the information for event-based job runs
+type Trigger struct {
+	FileArrival *FileArrival `json:"file_arrival"`
+	PauseStatus string       `json:"pause_status,omitempty" tf:"computed"`
+}
+
+// Continuous contains the information for jobs, that always have an active run
+type Continuous struct {
+	PauseStatus string `json:"pause_status,omitempty" tf:"computed"`
+}
+
+// JobParameterDefinition contains the information for a job-level parameter, that is pushed down to all tasks
+type JobParameterDefinition struct {
+	Name    string `json:"name"`
+	Default string `json:"default"`
+}
+
 type TaskDependency struct {
 	TaskKey string `json:"task_key,omitempty"`
+	Outcome string `json:"outcome,omitempty"`
 }
 
 type JobTaskSettings struct {
 	TaskKey     string           `json:"task_key,omitempty"`
 	Description string           `json:"description,omitempty"`
 	DependsOn   []TaskDependency `json:"depends_on,omitempty"`
-
-	ExistingClusterID      string              `json:"existing_cluster_id,omitempty" tf:"group:cluster_type"`
-	NewCluster             *clusters.Cluster   `json:"new_cluster,omitempty" tf:"group:cluster_type"`
-	Libraries              []libraries.Library `json:"libraries,omitempty" tf:"slice_set,alias:library"`
-	NotebookTask           *NotebookTask       `json:"notebook_task,omitempty" tf:"group:task_type"`
-	SparkJarTask           *SparkJarTask       `json:"spark_jar_task,omitempty" tf:"group:task_type"`
-	SparkPythonTask        *SparkPythonTask    `json:"spark_python_task,omitempty" tf:"group:task_type"`
-	SparkSubmitTask        *SparkSubmitTask    `json:"spark_submit_task,omitempty" tf:"group:task_type"`
-	PipelineTask           *PipelineTask       `json:"pipeline_task,omitempty" tf:"group:task_type"`
-	PythonWheelTask        *PythonWheelTask    `json:"python_wheel_task,omitempty" tf:"group:task_type"`
-	EmailNotifications     *EmailNotifications `json:"email_notifications,omitempty" tf:"suppress_diff"`
-	TimeoutSeconds         int32               `json:"timeout_seconds,omitempty"`
-	MaxRetries             int32               `json:"max_retries,omitempty"`
-	MinRetryIntervalMillis int32               `json:"min_retry_interval_millis,omitempty"`
-	RetryOnTimeout         bool                `json:"retry_on_timeout,omitempty" tf:"computed"`
+	RunIf       string           `json:"run_if,omitempty" tf:"computed"`
+
+	ExistingClusterID      string                `json:"existing_cluster_id,omitempty" tf:"group:cluster_type"`
+	NewCluster             *clusters.Cluster     `json:"new_cluster,omitempty" tf:"group:cluster_type"`
+	Libraries              []libraries.Library   `json:"libraries,omitempty" tf:"slice_set,alias:library"`
+	NotebookTask           *NotebookTask         `json:"notebook_task,omitempty" tf:"group:task_type"`
+	SparkJarTask           *SparkJarTask         `json:"spark_jar_task,omitempty" tf:"group:task_type"`
+	SparkPythonTask        *SparkPythonTask      `json:"spark_python_task,omitempty" tf:"group:task_type"`
+	SparkSubmitTask        *SparkSubmitTask      `json:"spark_submit_task,omitempty" tf:"group:task_type"`
+	PipelineTask           *PipelineTask         `json:"pipeline_task,omitempty" tf:"group:task_type"`
+	PythonWheelTask        *PythonWheelTask      `json:"python_wheel_task,omitempty" tf:"group:task_type"`
+	ConditionTask          *ConditionTask        `json:"condition_task,omitempty" tf:"group:task_type"`
+	EmailNotifications     *EmailNotifications   `json:"email_notifications,omitempty" tf:"suppress_diff"`
+	WebhookNotifications   *WebhookNotifications `json:"webhook_notifications,omitempty" tf:"suppress_diff"`
+	TimeoutSeconds         int32                 `json:"timeout_seconds,omitempty"`
+	MaxRetries             int32                 `json:"max_retries,omitempty"`
+	MinRetryIntervalMillis int32                 `json:"min_retry_interval_millis,omitempty"`
+	RetryOnTimeout         bool                  `json:"retry_on_timeout,omitempty" tf:"computed"`
 }
 
 // JobSettings contains the information for configuring a job on databricks
@@ -116,25 +164,241 @@ type JobSettings struct {
 	// END Jobs API 2.0
 
 	// BEGIN Jobs API 2.1
-	Tasks  []JobTaskSettings `json:"tasks,omitempty" tf:"alias:task"`
-	Format string            `json:"format,omitempty" tf:"computed"`
+	Tasks      []JobTaskSettings        `json:"tasks,omitempty" tf:"alias:task"`
+	Format     string                   `json:"format,omitempty" tf:"computed"`
+	Parameters []JobParameterDefinition `json:"parameters,omitempty" tf:"alias:parameter"`
 	// END Jobs API 2.1
 
-	Schedule           *CronSchedule       `json:"schedule,omitempty"`
-	MaxConcurrentRuns  int32               `json:"max_concurrent_runs,omitempty"`
-	EmailNotifications *EmailNotifications `json:"email_notifications,omitempty" tf:"suppress_diff"`
+	Schedule             *CronSchedule         `json:"schedule,omitempty"`
+	Trigger              *Trigger              `json:"trigger,omitempty"`
+	Continuous           *Continuous           `json:"continuous,omitempty"`
+	MaxConcurrentRuns    int32                 `json:"max_concurrent_runs,omitempty"`
+	EmailNotifications   *EmailNotifications   `json:"email_notifications,omitempty" tf:"suppress_diff"`
+	WebhookNotifications *WebhookNotifications `json:"webhook_notifications,omitempty" tf:"suppress_diff"`
 }
 
 func (js *JobSettings) isMultiTask() bool {
 	return js.Format == "MULTI_TASK" || len(js.Tasks) > 0
 }
 
+// applyPolicyDefaultValues merges fixed and default values of cluster policies
+// into new clusters of the job and its tasks
+func (js *JobSettings) applyPolicyDefaultValues(clustersAPI clusters.ClustersAPI) error {
+	if js.NewCluster != nil {
+		if err := clustersAPI.ApplyPolicyDefaultValues(js.NewCluster); err != nil {
+			return err
+		}
+	}
+	for _, task := range js.Tasks {
+		if task.NewCluster == nil {
+			continue
+		}
+		if err := clustersAPI.ApplyPolicyDefaultValues(task.NewCluster); err != nil {
+			return fmt.Errorf("task %s: %w", task.TaskKey, err)
+		}
+	}
+	return nil
+}
+
+// removePolicyPresets ignores values, that were set by cluster policies and are not in
+// configured job settings, so that jobs with `apply_policy_default_values` don't report drift
+func (js *JobSettings) removePolicyPresets(clustersAPI clusters.ClustersAPI, configured JobSettings) error {
+	keep := func(path string) bool {
+		s, err := common.SchemaPath(jobSchema, "new_cluster", strings.SplitN(path, ".", 2)[0])
+		return err == nil && s.Computed
+	}
+	if js.NewCluster != nil && configured.NewCluster != nil {
+		err := clustersAPI.RemovePolicyPresets(*configured.NewCluster, js.NewCluster, keep)
+		if err != nil {
+			return err
+		}
+	}
+	configuredClusters := map[string]*clusters.Cluster{}
+	for _, task := range configured.Tasks {
+		configuredClusters[task.TaskKey] = task.NewCluster
+	}
+	for _, task := range js.Tasks {
+		cluster := configuredClusters[task.TaskKey]
+		if task.NewCluster == nil || cluster == nil {
+			continue
+		}
+		if err := clustersAPI.RemovePolicyPresets(*cluster, task.NewCluster, keep); err != nil {
+			return fmt.Errorf("task %s: %w", task.TaskKey, err)
+		}
+	}
+	return nil
+}
+
 func (js *JobSettings) sortTasksByKey() {
 	sort.Slice(js.Tasks, func(i, j int) bool {
 		return js.Tasks[i].TaskKey < js.Tasks[j].TaskKey
 	})
 }
 
+// validateTaskGraph checks, that task dependencies form a directed acyclic graph, where
+// every task could run. Tasks with keys, that are not known during plan, are skipped with
+// their dependencies, and references to missing tasks are reported only if all keys are known.
+func (js *JobSettings) validateTaskGraph() error {
+	tasks := map[string]*JobTaskSettings{}
+	keys := []string{}
+	problems := []string{}
+	allKnown := true
+	for i, task := range js.Tasks {
+		if task.TaskKey == "" {
+			// keys of dynamically generated tasks may not be known during plan
+			allKnown = false
+			continue
+		}
+		if _, ok := tasks[task.TaskKey]; ok {
+			problems = append(problems, fmt.Sprintf("task %s is defined more than once", task.TaskKey))
+			continue
+		}
+		tasks[task.TaskKey] = &js.Tasks[i]
+		keys = append(keys, task.TaskKey)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for _, dep := range tasks[key].DependsOn {
+			upstream, ok := tasks[dep.TaskKey]
+			if !ok {
+				if allKnown && dep.TaskKey != "" {
+					problems = append(problems, fmt.Sprintf("task %s depends on missing task %s", key, dep.TaskKey))
+				}
+				continue
+			}
+			if dep.Outcome != "" && upstream.ConditionTask == nil {
+				problems = append(problems, fmt.Sprintf(
+					"task %s depends on outcome of task %s, which is not a condition_task", key, dep.TaskKey))
+			}
+		}
+	}
+	// depth-first search with the stack of currently visited tasks to find back edges
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := map[string]int{}
+	inCycle := map[string]bool{}
+	stack := []string{}
+	var visit func(key string)
+	visit = func(key string) {
+		state[key] = visiting
+		stack = append(stack, key)
+		for _, dep := range tasks[key].DependsOn {
+			if _, ok := tasks[dep.TaskKey]; !ok {
+				continue
+			}
+			switch state[dep.TaskKey] {
+			case unvisited:
+				visit(dep.TaskKey)
+			case visiting:
+				start := len(stack) - 1
+				for stack[start] != dep.TaskKey {
+					start--
+				}
+				cycle := append([]string{}, stack[start:]...)
+				for _, k := range cycle {
+					inCycle[k] = true
+				}
+				// dependencies point upstream, so we reverse the path to follow execution order
+				for i, j := 0, len(cycle)-1; i < j; i, j = i+1, j-1 {
+					cycle[i], cycle[j] = cycle[j], cycle[i]
+				}
+				// and start from the first key in alphabetical order for stable messages
+				first := 0
+				for i, k := range cycle {
+					if k < cycle[first] {
+						first = i
+					}
+				}
+				cycle = append(cycle[first:], cycle[:first]...)
+				problems = append(problems, fmt.Sprintf("tasks form a dependency cycle: %s -> %s",
+					strings.Join(cycle, " -> "), cycle[0]))
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[key] = visited
+	}
+	for _, key := range keys {
+		if state[key] == unvisited {
+			visit(key)
+		}
+	}
+	// tasks downstream of cycles or missing tasks could never run
+	const (
+		missingUpstream = "a missing task"
+		cycleUpstream   = "a dependency cycle"
+	)
+	blocked := map[string]string{}
+	var blockedBy func(key string) string
+	blockedBy = func(key string) string {
+		if reason, ok := blocked[key]; ok {
+			return reason
+		}
+		blocked[key] = ""
+		for _, dep := range tasks[key].DependsOn {
+			if _, ok := tasks[dep.TaskKey]; !ok {
+				if allKnown && dep.TaskKey != "" && blocked[key] == "" {
+					blocked[key] = missingUpstream
+				}
+				continue
+			}
+			if inCycle[dep.TaskKey] {
+				blocked[key] = cycleUpstream
+				break
+			}
+			if reason := blockedBy(dep.TaskKey); reason != "" && blocked[key] == "" {
+				blocked[key] = reason
+			}
+		}
+		return blocked[key]
+	}
+	for _, key := range keys {
+		if inCycle[key] {
+			continue
+		}
+		reason := blockedBy(key)
+		if reason == "" || (reason == missingUpstream && dependsOnMissing(tasks, key)) {
+			// tasks, that directly depend on missing tasks, are already reported
+			continue
+		}
+		problems = append(problems, fmt.Sprintf(
+			"task %s is unreachable, because it depends on %s", key, reason))
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid task dependencies: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// dependsOnMissing returns true, if the task directly depends on the task, that is not defined
+func dependsOnMissing(tasks map[string]*JobTaskSettings, key string) bool {
+	for _, dep := range tasks[key].DependsOn {
+		if _, ok := tasks[dep.TaskKey]; !ok && dep.TaskKey != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (js *JobSettings) validateParameters() error {
+	if len(js.Parameters) == 0 {
+		return nil
+	}
+	if len(js.Tasks) == 0 {
+		return fmt.Errorf("`parameter` blocks are supported only for jobs with `task` blocks")
+	}
+	seen := map[string]bool{}
+	for _, p := range js.Parameters {
+		if seen[p.Name] {
+			return fmt.Errorf("parameter %s is defined more than once", p.Name)
+		}
+		seen[p.Name] = true
+	}
+	return nil
+}
+
 // JobList returns a list of all jobs
 type JobList struct {
 	Jobs []Job `json:"jobs"`
@@ -162,6 +426,7 @@ type RunParameters struct {
 	JarParams         []string          `json:"jar_params,omitempty"`
 	PythonParams      []string          `json:"python_params,omitempty"`
 	SparkSubmitParams []string          `json:"spark_submit_params,omitempty"`
+	JobParameters     map[string]string `json:"job_parameters,omitempty"`
 }
 
 // RunState of the job
@@ -406,12 +671,42 @@ func jobSettingsSchema(s *map[string]*schema.Schema, prefix string) {
 	}
 }
 
+func conflictingKeys(key string, group []string) (conflicts []string) {
+	for _, other := range group {
+		if other != key {
+			conflicts = append(conflicts, other)
+		}
+	}
+	return
+}
+
 var jobSchema = common.StructToSchema(JobSettings{},
 	func(s map[string]*schema.Schema) map[string]*schema.Schema {
 		jobSettingsSchema(&s, "")
 		jobSettingsSchema(&s["task"].Elem.(*schema.Resource).Schema, "task.0.")
-		if p, err := common.SchemaPath(s, "schedule", "pause_status"); err == nil {
-			p.ValidateFunc = validation.StringInSlice([]string{"PAUSED", "UNPAUSED"}, false)
+		if p, err := common.SchemaPath(s, "task", "run_if"); err == nil {
+			p.ValidateFunc = validation.StringInSlice([]string{"ALL_SUCCESS", "AT_LEAST_ONE_SUCCESS",
+				"NONE_FAILED", "ALL_DONE", "AT_LEAST_ONE_FAILED", "ALL_FAILED"}, false)
+		}
+		if p, err := common.SchemaPath(s, "task", "condition_task", "op"); err == nil {
+			p.ValidateFunc = validation.StringInSlice([]string{"EQUAL_TO", "NOT_EQUAL", "GREATER_THAN",
+				"GREATER_THAN_OR_EQUAL", "LESS_THAN", "LESS_THAN_OR_EQUAL"}, false)
+		}
+		if p, err := common.SchemaPath(s, "task", "depends_on", "outcome"); err == nil {
+			p.ValidateFunc = validation.StringInSlice([]string{"true", "false"}, false)
+		}
+		jobTriggerTypes := []string{"schedule", "trigger", "continuous"}
+		for _, triggerType := range jobTriggerTypes {
+			s[triggerType].ConflictsWith = conflictingKeys(triggerType, jobTriggerTypes)
+			if p, err := common.SchemaPath(s, triggerType, "pause_status"); err == nil {
+				p.ValidateFunc = validation.StringInSlice([]string{"PAUSED", "UNPAUSED"}, false)
+			}
+		}
+		if p, err := common.SchemaPath(s, "trigger", "file_arrival", "url"); err == nil {
+			// backend adds a trailing slash to directory locations
+			p.DiffSuppressFunc = func(k, old, new string, d *schema.ResourceData) bool {
+				return strings.TrimSuffix(old, "/") == strings.TrimSuffix(new, "/")
+			}
 		}
 		s["max_concurrent_runs"].ValidateDiagFunc = validation.ToDiagFunc(validation.IntAtLeast(1))
 		s["max_concurrent_runs"].Default = 1
@@ -457,18 +752,46 @@ func ResourceJob() *schema.Resource {
 			if alwaysRunning && js.MaxConcurrentRuns > 1 {
 				return fmt.Errorf("`always_running` must be specified only with `max_concurrent_runs = 1`")
 			}
-			for _, task := range js.Tasks {
+			if alwaysRunning && js.Continuous != nil {
+				return fmt.Errorf("`always_running` cannot be used together with `continuous` block")
+			}
+			if js.Continuous != nil && js.MaxConcurrentRuns > 1 {
+				return fmt.Errorf("`continuous` must be specified only with `max_concurrent_runs = 1`")
+			}
+			if err = js.validateParameters(); err != nil {
+				return err
+			}
+			if err = js.validateTaskGraph(); err != nil {
+				return err
+			}
+			c := m.(*common.DatabricksClient)
+			for i, task := range js.Tasks {
 				if task.NewCluster == nil {
 					continue
 				}
 				if err = task.NewCluster.Validate(); err != nil {
 					return fmt.Errorf("task %s invalid: %w", task.TaskKey, err)
 				}
+				prefix := fmt.Sprintf("task.%d.new_cluster.0.", i)
+				err = clusters.ValidatePolicyCompliance(ctx, c, *task.NewCluster, clusters.ClusterTypeJob,
+					func(attr string) bool {
+						return d.NewValueKnown(prefix + attr)
+					})
+				if err != nil {
+					return fmt.Errorf("task %s invalid: %w", task.TaskKey, err)
+				}
 			}
 			if js.NewCluster != nil {
 				if err = js.NewCluster.Validate(); err != nil {
 					return fmt.Errorf("invalid job cluster: %w", err)
 				}
+				err = clusters.ValidatePolicyCompliance(ctx, c, *js.NewCluster, clusters.ClusterTypeJob,
+					func(attr string) bool {
+						return d.NewValueKnown("new_cluster.0." + attr)
+					})
+				if err != nil {
+					return fmt.Errorf("invalid job cluster: %w", err)
+				}
 			}
 			return nil
 		},
@@ -478,6 +801,9 @@ func ResourceJob() *schema.Resource {
 			if err != nil {
 				return err
 			}
+			if err = js.applyPolicyDefaultValues(clusters.NewClustersAPI(ctx, c)); err != nil {
+				return err
+			}
 			if js.isMultiTask() {
 				ctx = context.WithValue(ctx, common.Api, common.API_2_1)
 			}
@@ -493,8 +819,16 @@ func ResourceJob() *schema.Resource {
 			return nil
 		},
 		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
-			ctx = getReadCtx(ctx, d)
-			job, err := NewJobsAPI(ctx, c).Read(d.Id())
+			job, err := NewJobsAPI(getReadCtx(ctx, d), c).Read(d.Id())
+			if err != nil {
+				return err
+			}
+			var configured JobSettings
+			if err = common.DataToStructPointer(d, jobSchema, &configured); err != nil {
+				return err
+			}
+			// policies API is not versioned with jobs, so the original context is used
+			err = job.Settings.removePolicyPresets(clusters.NewClustersAPI(ctx, c), configured)
 			if err != nil {
 				return err
 			}
@@ -507,6 +841,9 @@ func ResourceJob() *schema.Resource {
 			if err != nil {
 				return err
 			}
+			if err = js.applyPolicyDefaultValues(clusters.NewClustersAPI(ctx, c)); err != nil {
+				return err
+			}
 			if js.isMultiTask() {
 				ctx = context.WithValue(ctx, common.Api, common.API_2_1)
 			}
@@ -9,6 +9,7 @@ import (
 	"github.com/databrickslabs/terraform-provider-databricks/clusters"
 	"github.com/databrickslabs/terraform-provider-databricks/common"
 	"github.com/databrickslabs/terraform-provider-databricks/libraries"
+	"github.com/databrickslabs/terraform-provider-databricks/policies"
 	"github.com/databrickslabs/terraform-provider-databricks/qa"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -207,6 +208,418 @@ func TestResourceJobCreate_MultiTask(t *testing.T) {
 	assert.Equal(t, "789", d.Id())
 }
 
+func TestResourceJobCreate_WebhookNotifications(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.1/jobs/create",
+				ExpectedRequest: JobSettings{
+					Name: "Featurizer",
+					Tasks: []JobTaskSettings{
+						{
+							TaskKey:           "a",
+							ExistingClusterID: "abc",
+							NotebookTask: &NotebookTask{
+								NotebookPath: "/Stuff",
+							},
+							WebhookNotifications: &WebhookNotifications{
+								OnFailure: []Webhook{{ID: "task-pagerduty"}},
+							},
+						},
+					},
+					MaxConcurrentRuns: 1,
+					WebhookNotifications: &WebhookNotifications{
+						OnStart:   []Webhook{{ID: "slack"}},
+						OnSuccess: []Webhook{{ID: "slack"}},
+						OnFailure: []Webhook{{ID: "slack"}, {ID: "pagerduty"}},
+					},
+				},
+				Response: Job{
+					JobID: 789,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/get?job_id=789",
+				Response: Job{
+					JobID: 789,
+					Settings: &JobSettings{
+						Name: "Featurizer",
+						Tasks: []JobTaskSettings{
+							{
+								TaskKey:           "a",
+								ExistingClusterID: "abc",
+								NotebookTask: &NotebookTask{
+									NotebookPath: "/Stuff",
+								},
+								WebhookNotifications: &WebhookNotifications{
+									OnFailure: []Webhook{{ID: "task-pagerduty"}},
+								},
+							},
+						},
+						MaxConcurrentRuns: 1,
+						WebhookNotifications: &WebhookNotifications{
+							OnStart:   []Webhook{{ID: "slack"}},
+							OnSuccess: []Webhook{{ID: "slack"}},
+							OnFailure: []Webhook{{ID: "slack"}, {ID: "pagerduty"}},
+						},
+					},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		name = "Featurizer"
+
+		webhook_notifications {
+			on_start {
+				id = "slack"
+			}
+			on_success {
+				id = "slack"
+			}
+			on_failure {
+				id = "slack"
+			}
+			on_failure {
+				id = "pagerduty"
+			}
+		}
+
+		task {
+			task_key = "a"
+			existing_cluster_id = "abc"
+			notebook_task {
+				notebook_path = "/Stuff"
+			}
+			webhook_notifications {
+				on_failure {
+					id = "task-pagerduty"
+				}
+			}
+		}`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "789", d.Id())
+	assert.Equal(t, "pagerduty", d.Get("webhook_notifications.0.on_failure.1.id"))
+	assert.Equal(t, "task-pagerduty", d.Get("task.0.webhook_notifications.0.on_failure.0.id"))
+}
+
+func TestResourceJobCreate_ParametersAndConditions(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.1/jobs/create",
+				ExpectedRequest: JobSettings{
+					Name: "Featurizer",
+					Parameters: []JobParameterDefinition{
+						{
+							Name:    "env",
+							Default: "dev",
+						},
+						{
+							Name:    "full_refresh",
+							Default: "false",
+						},
+					},
+					Tasks: []JobTaskSettings{
+						{
+							TaskKey: "a",
+							ConditionTask: &ConditionTask{
+								Left:  "{{job.parameters.full_refresh}}",
+								Op:    "EQUAL_TO",
+								Right: "true",
+							},
+						},
+						{
+							TaskKey: "b",
+							DependsOn: []TaskDependency{
+								{
+									TaskKey: "a",
+									Outcome: "true",
+								},
+							},
+							ExistingClusterID: "abc",
+							NotebookTask: &NotebookTask{
+								NotebookPath: "/Refresh",
+							},
+						},
+						{
+							TaskKey: "c",
+							DependsOn: []TaskDependency{
+								{
+									TaskKey: "b",
+								},
+							},
+							RunIf:             "AT_LEAST_ONE_FAILED",
+							ExistingClusterID: "abc",
+							NotebookTask: &NotebookTask{
+								NotebookPath: "/Cleanup",
+							},
+						},
+					},
+					MaxConcurrentRuns: 1,
+				},
+				Response: Job{
+					JobID: 789,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/get?job_id=789",
+				Response: Job{
+					JobID: 789,
+					Settings: &JobSettings{
+						Name: "Featurizer",
+						Parameters: []JobParameterDefinition{
+							{
+								Name:    "env",
+								Default: "dev",
+							},
+							{
+								Name:    "full_refresh",
+								Default: "false",
+							},
+						},
+						Tasks: []JobTaskSettings{
+							{
+								TaskKey: "c",
+								RunIf:   "AT_LEAST_ONE_FAILED",
+							},
+							{
+								TaskKey: "a",
+								RunIf:   "ALL_SUCCESS",
+							},
+							{
+								TaskKey: "b",
+								RunIf:   "ALL_SUCCESS",
+							},
+						},
+					},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		name = "Featurizer"
+
+		parameter {
+			name = "env"
+			default = "dev"
+		}
+
+		parameter {
+			name = "full_refresh"
+			default = "false"
+		}
+
+		task {
+			task_key = "a"
+			condition_task {
+				left = "{{job.parameters.full_refresh}}"
+				op = "EQUAL_TO"
+				right = "true"
+			}
+		}
+
+		task {
+			task_key = "b"
+			depends_on {
+				task_key = "a"
+				outcome = "true"
+			}
+			existing_cluster_id = "abc"
+			notebook_task {
+				notebook_path = "/Refresh"
+			}
+		}
+
+		task {
+			task_key = "c"
+			depends_on {
+				task_key = "b"
+			}
+			run_if = "AT_LEAST_ONE_FAILED"
+			existing_cluster_id = "abc"
+			notebook_task {
+				notebook_path = "/Cleanup"
+			}
+		}`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "789", d.Id())
+	assert.Equal(t, "full_refresh", d.Get("parameter.1.name"))
+	assert.Equal(t, "ALL_SUCCESS", d.Get("task.0.run_if"))
+	assert.Equal(t, "AT_LEAST_ONE_FAILED", d.Get("task.2.run_if"))
+}
+
+func TestResourceJobCreate_ParametersWithoutTasks(t *testing.T) {
+	qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		existing_cluster_id = "abc"
+		notebook_task {
+			notebook_path = "/Stuff"
+		}
+		parameter {
+			name = "env"
+			default = "dev"
+		}`,
+	}.ExpectError(t, "`parameter` blocks are supported only for jobs with `task` blocks")
+}
+
+func TestResourceJobCreate_DuplicateParameters(t *testing.T) {
+	qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		parameter {
+			name = "env"
+			default = "dev"
+		}
+		parameter {
+			name = "env"
+			default = "prod"
+		}
+		task {
+			task_key = "a"
+			existing_cluster_id = "abc"
+			notebook_task {
+				notebook_path = "/Stuff"
+			}
+		}`,
+	}.ExpectError(t, "parameter env is defined more than once")
+}
+
+func TestResourceJobCreate_InvalidRunIf(t *testing.T) {
+	qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		task {
+			task_key = "a"
+			run_if = "SOMETIMES"
+			existing_cluster_id = "abc"
+			notebook_task {
+				notebook_path = "/Stuff"
+			}
+		}`,
+	}.ExpectError(t, "invalid config supplied. [task.#.run_if] expected task.0.run_if to be one of "+
+		"[ALL_SUCCESS AT_LEAST_ONE_SUCCESS NONE_FAILED ALL_DONE AT_LEAST_ONE_FAILED ALL_FAILED], got SOMETIMES")
+}
+
+func TestResourceJobCreate_DependencyCycle(t *testing.T) {
+	qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		task {
+			task_key = "a"
+			depends_on {
+				task_key = "c"
+			}
+			existing_cluster_id = "abc"
+			notebook_task {
+				notebook_path = "/Stuff"
+			}
+		}
+		task {
+			task_key = "b"
+			depends_on {
+				task_key = "a"
+			}
+			existing_cluster_id = "abc"
+			notebook_task {
+				notebook_path = "/Stuff"
+			}
+		}
+		task {
+			task_key = "c"
+			depends_on {
+				task_key = "b"
+			}
+			existing_cluster_id = "abc"
+			notebook_task {
+				notebook_path = "/Stuff"
+			}
+		}`,
+	}.ExpectError(t, "invalid task dependencies: tasks form a dependency cycle: a -> b -> c -> a")
+}
+
+func TestJobSettingsValidateTaskGraph(t *testing.T) {
+	task := func(key string, deps ...string) JobTaskSettings {
+		jts := JobTaskSettings{TaskKey: key}
+		for _, dep := range deps {
+			jts.DependsOn = append(jts.DependsOn, TaskDependency{TaskKey: dep})
+		}
+		return jts
+	}
+	for name, tc := range map[string]struct {
+		tasks []JobTaskSettings
+		err   string
+	}{
+		"valid": {
+			tasks: []JobTaskSettings{task("a"), task("b", "a"), task("c", "a", "b"), task("d")},
+		},
+		"unknown keys": {
+			tasks: []JobTaskSettings{task(""), task("a", "x"), task("b", "a", ""), task("c", "x")},
+		},
+		"unknown keys with cycle": {
+			tasks: []JobTaskSettings{task(""), task("b", "b"), task("c", "b"), task("c")},
+			err: "invalid task dependencies: task c is defined more than once; " +
+				"tasks form a dependency cycle: b -> b; " +
+				"task c is unreachable, because it depends on a dependency cycle",
+		},
+		"duplicate": {
+			tasks: []JobTaskSettings{task("a"), task("b", "a"), task("a")},
+			err:   "invalid task dependencies: task a is defined more than once",
+		},
+		"missing": {
+			tasks: []JobTaskSettings{task("a", "x"), task("b", "a", "y")},
+			err: "invalid task dependencies: task a depends on missing task x; " +
+				"task b depends on missing task y",
+		},
+		"downstream of missing": {
+			tasks: []JobTaskSettings{task("a", "x"), task("b", "a"), task("c", "b"), task("d")},
+			err: "invalid task dependencies: task a depends on missing task x; " +
+				"task b is unreachable, because it depends on a missing task; " +
+				"task c is unreachable, because it depends on a missing task",
+		},
+		"self": {
+			tasks: []JobTaskSettings{task("a", "a")},
+			err:   "invalid task dependencies: tasks form a dependency cycle: a -> a",
+		},
+		"unreachable": {
+			tasks: []JobTaskSettings{task("a", "b"), task("b", "a"), task("c", "b"), task("d", "c"), task("e")},
+			err: "invalid task dependencies: tasks form a dependency cycle: a -> b -> a; " +
+				"task c is unreachable, because it depends on a dependency cycle; " +
+				"task d is unreachable, because it depends on a dependency cycle",
+		},
+		"outcome": {
+			tasks: []JobTaskSettings{task("a"), {
+				TaskKey:   "b",
+				DependsOn: []TaskDependency{{TaskKey: "a", Outcome: "true"}},
+			}},
+			err: "invalid task dependencies: task b depends on outcome of task a, which is not a condition_task",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			js := JobSettings{Tasks: tc.tasks}
+			err := js.validateTaskGraph()
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
+
 func TestResourceJobCreate_AlwaysRunning(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -287,6 +700,318 @@ func TestResourceJobCreate_AlwaysRunning_Conflict(t *testing.T) {
 	}.ExpectError(t, "`always_running` must be specified only with `max_concurrent_runs = 1`")
 }
 
+func TestResourceJobCreate_FileArrivalTrigger(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/jobs/create",
+				ExpectedRequest: JobSettings{
+					ExistingClusterID: "abc",
+					NotebookTask: &NotebookTask{
+						NotebookPath: "/Stuff",
+					},
+					Name:              "Featurizer",
+					MaxConcurrentRuns: 1,
+					Trigger: &Trigger{
+						FileArrival: &FileArrival{
+							URL:                           "s3://bucket/landing",
+							MinTimeBetweenTriggersSeconds: 60,
+						},
+						PauseStatus: "UNPAUSED",
+					},
+				},
+				Response: Job{
+					JobID: 789,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/get?job_id=789",
+				Response: Job{
+					JobID: 789,
+					Settings: &JobSettings{
+						ExistingClusterID: "abc",
+						NotebookTask: &NotebookTask{
+							NotebookPath: "/Stuff",
+						},
+						Name:              "Featurizer",
+						MaxConcurrentRuns: 1,
+						Trigger: &Trigger{
+							FileArrival: &FileArrival{
+								URL:                           "s3://bucket/landing/",
+								MinTimeBetweenTriggersSeconds: 60,
+							},
+							PauseStatus: "UNPAUSED",
+						},
+					},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		existing_cluster_id = "abc"
+		name = "Featurizer"
+		notebook_task {
+			notebook_path = "/Stuff"
+		}
+		trigger {
+			file_arrival {
+				url = "s3://bucket/landing"
+				min_time_between_triggers_seconds = 60
+			}
+			pause_status = "UNPAUSED"
+		}`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "789", d.Id())
+	assert.Equal(t, "s3://bucket/landing/", d.Get("trigger.0.file_arrival.0.url"))
+}
+
+func TestResourceJobCreate_Continuous(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/jobs/create",
+				ExpectedRequest: JobSettings{
+					ExistingClusterID: "abc",
+					NotebookTask: &NotebookTask{
+						NotebookPath: "/Stuff",
+					},
+					Name:              "Featurizer",
+					MaxConcurrentRuns: 1,
+					Continuous: &Continuous{
+						PauseStatus: "PAUSED",
+					},
+				},
+				Response: Job{
+					JobID: 789,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/get?job_id=789",
+				Response: Job{
+					JobID: 789,
+					Settings: &JobSettings{
+						ExistingClusterID: "abc",
+						NotebookTask: &NotebookTask{
+							NotebookPath: "/Stuff",
+						},
+						Name:              "Featurizer",
+						MaxConcurrentRuns: 1,
+						Continuous: &Continuous{
+							PauseStatus: "PAUSED",
+						},
+					},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		existing_cluster_id = "abc"
+		name = "Featurizer"
+		notebook_task {
+			notebook_path = "/Stuff"
+		}
+		continuous {
+			pause_status = "PAUSED"
+		}`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "PAUSED", d.Get("continuous.0.pause_status"))
+}
+
+func TestResourceJobCreate_ScheduleAndTriggerConflict(t *testing.T) {
+	qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		schedule {
+			quartz_cron_expression = "0 15 22 ? * *"
+			timezone_id = "America/Los_Angeles"
+		}
+		trigger {
+			file_arrival {
+				url = "s3://bucket/landing"
+			}
+		}`,
+	}.ExpectError(t, "invalid config supplied. "+
+		"[schedule] Conflicting configuration arguments. "+
+		"[trigger] Conflicting configuration arguments")
+}
+
+func TestResourceJobCreate_ContinuousAlwaysRunningConflict(t *testing.T) {
+	qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		always_running = true
+		continuous {}
+		`,
+	}.ExpectError(t, "`always_running` cannot be used together with `continuous` block")
+}
+
+func TestResourceJobCreate_PolicyViolations(t *testing.T) {
+	policy := qa.HTTPFixture{
+		Method:       "GET",
+		Resource:     "/api/2.0/policies/clusters/get?policy_id=abc",
+		ReuseRequest: true,
+		Response: policies.ClusterPolicy{
+			PolicyID: "abc",
+			Definition: `{
+				"cluster_type": {"type": "fixed", "value": "job"},
+				"spark_version": {"type": "regex", "pattern": "7\\..*"},
+				"num_workers": {"type": "range", "maxValue": 2}
+			}`,
+		},
+	}
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{policy},
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		task {
+			task_key = "a"
+			new_cluster {
+				policy_id = "abc"
+				spark_version = "7.3.x-scala2.12"
+				node_type_id = "i3.xlarge"
+				num_workers = 1
+			}
+			notebook_task {
+				notebook_path = "/Stuff"
+			}
+		}
+		task {
+			task_key = "b"
+			new_cluster {
+				policy_id = "abc"
+				spark_version = "6.4.x-scala2.11"
+				node_type_id = "i3.xlarge"
+				num_workers = 8
+			}
+			notebook_task {
+				notebook_path = "/Stuff"
+			}
+		}`,
+	}.ExpectError(t, "task b invalid: cluster does not comply with policy abc: "+
+		"num_workers must be at most 2, but is 8; "+
+		"spark_version must match 7\\..*, but is 6.4.x-scala2.11")
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{policy},
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		new_cluster {
+			policy_id = "abc"
+			spark_version = "7.3.x-scala2.12"
+			node_type_id = "i3.xlarge"
+			num_workers = 4
+		}
+		spark_jar_task {
+			main_class_name = "com.acme.Main"
+		}`,
+	}.ExpectError(t, "invalid job cluster: cluster does not comply with policy abc: "+
+		"num_workers must be at most 2, but is 4")
+}
+
+func TestResourceJobCreate_ApplyPolicyDefaultValues(t *testing.T) {
+	cluster := clusters.Cluster{
+		PolicyID:                 "abc",
+		ApplyPolicyDefaultValues: true,
+		SparkVersion:             "7.3.x-scala2.12",
+		NodeTypeID:               "i3.xlarge",
+		NumWorkers:               1,
+		CustomTags: map[string]string{
+			"Team": "data",
+		},
+		SparkConf: map[string]string{
+			"spark.databricks.io.cache.enabled": "true",
+		},
+	}
+	settings := JobSettings{
+		Name:              "Untitled",
+		MaxConcurrentRuns: 1,
+		Tasks: []JobTaskSettings{
+			{
+				TaskKey:    "a",
+				NewCluster: &cluster,
+				NotebookTask: &NotebookTask{
+					NotebookPath: "/Stuff",
+				},
+			},
+		},
+	}
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/policies/clusters/get?policy_id=abc",
+				ReuseRequest: true,
+				Response: policies.ClusterPolicy{
+					PolicyID: "abc",
+					Definition: `{
+						"custom_tags.Team": {"type": "fixed", "value": "data"},
+						"spark_conf.spark.databricks.io.cache.enabled": {"type": "fixed", "value": true},
+						"node_type_id": {"type": "allowlist", "values": ["i3.xlarge"], "defaultValue": "i3.xlarge"}
+					}`,
+				},
+			},
+			{
+				Method:          "POST",
+				Resource:        "/api/2.1/jobs/create",
+				ExpectedRequest: settings,
+				Response: Job{
+					JobID: 789,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/get?job_id=789",
+				Response: Job{
+					JobID:    789,
+					Settings: &settings,
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		task {
+			task_key = "a"
+			new_cluster {
+				policy_id = "abc"
+				apply_policy_default_values = true
+				spark_version = "7.3.x-scala2.12"
+				num_workers = 1
+			}
+			notebook_task {
+				notebook_path = "/Stuff"
+			}
+		}`,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "789", d.Id())
+	assert.Equal(t, "i3.xlarge", d.Get("task.0.new_cluster.0.node_type_id"))
+	assert.Len(t, d.Get("task.0.new_cluster.0.custom_tags"), 0)
+	assert.Len(t, d.Get("task.0.new_cluster.0.spark_conf"), 0)
+}
+
+func TestJobResource_TriggerDiffSuppress(t *testing.T) {
+	jr := ResourceJob()
+	url := common.MustSchemaPath(jr.Schema, "trigger", "file_arrival", "url")
+	assert.True(t, url.DiffSuppressFunc("trigger.0.file_arrival.0.url", "s3://a/b/", "s3://a/b", nil))
+	assert.False(t, url.DiffSuppressFunc("trigger.0.file_arrival.0.url", "s3://a/b/", "s3://a/c", nil))
+	ps := common.MustSchemaPath(jr.Schema, "continuous", "pause_status")
+	assert.Nil(t, ps.DiffSuppressFunc)
+	_, errs := ps.ValidateFunc("paused", "continuous.0.pause_status")
+	assert.Len(t, errs, 1)
+}
+
 func TestResourceJobCreateSingleNode(t *testing.T) {
 	cluster := clusters.Cluster{
 		NumWorkers: 0, SparkVersion: "7.3.x-scala2.12", NodeTypeID: "Standard_DS3_v2",
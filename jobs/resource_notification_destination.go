@@ -0,0 +1,125 @@
+package jobs
+
+import (
+	"context"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// SlackConfig contains the incoming webhook URL of a Slack channel
+type SlackConfig struct {
+	URL string `json:"url" tf:"sensitive"`
+}
+
+// PagerdutyConfig contains the integration key of a PagerDuty service
+type PagerdutyConfig struct {
+	IntegrationKey string `json:"integration_key" tf:"sensitive"`
+}
+
+// GenericWebhookConfig contains the information for arbitrary HTTP endpoint
+type GenericWebhookConfig struct {
+	URL      string `json:"url" tf:"sensitive"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty" tf:"sensitive"`
+}
+
+// NotificationDestinationConfig contains exactly one of destination configurations
+type NotificationDestinationConfig struct {
+	Slack          *SlackConfig          `json:"slack,omitempty" tf:"group:destination"`
+	Pagerduty      *PagerdutyConfig      `json:"pagerduty,omitempty" tf:"group:destination"`
+	GenericWebhook *GenericWebhookConfig `json:"generic_webhook,omitempty" tf:"group:destination"`
+}
+
+// NotificationDestination is the entity, which could be referenced from `webhook_notifications`
+type NotificationDestination struct {
+	ID              string                         `json:"id,omitempty" tf:"computed"`
+	DisplayName     string                         `json:"display_name"`
+	DestinationType string                         `json:"destination_type,omitempty" tf:"computed"`
+	Config          *NotificationDestinationConfig `json:"config"`
+}
+
+// NotificationDestinationsAPI exposes the Notification Destinations API
+type NotificationDestinationsAPI struct {
+	client  *common.DatabricksClient
+	context context.Context
+}
+
+// NewNotificationDestinationsAPI creates NotificationDestinationsAPI instance from provider meta
+func NewNotificationDestinationsAPI(ctx context.Context, m interface{}) NotificationDestinationsAPI {
+	return NotificationDestinationsAPI{m.(*common.DatabricksClient), ctx}
+}
+
+// Create creates notification destination and fills in its ID and type
+func (a NotificationDestinationsAPI) Create(nd *NotificationDestination) error {
+	return a.client.Post(a.context, "/notification-destinations", nd, nd)
+}
+
+// Read returns notification destination. Secrets, like URLs or keys, are never returned
+func (a NotificationDestinationsAPI) Read(id string) (nd NotificationDestination, err error) {
+	err = a.client.Get(a.context, "/notification-destinations/"+id, nil, &nd)
+	return
+}
+
+// Update changes the display name or configuration of notification destination
+func (a NotificationDestinationsAPI) Update(id string, nd NotificationDestination) error {
+	return a.client.Patch(a.context, "/notification-destinations/"+id, nd)
+}
+
+// Delete removes notification destination
+func (a NotificationDestinationsAPI) Delete(id string) error {
+	return a.client.Delete(a.context, "/notification-destinations/"+id, nil)
+}
+
+// ResourceNotificationDestination manages Slack, PagerDuty and generic webhook destinations
+func ResourceNotificationDestination() *schema.Resource {
+	s := common.StructToSchema(NotificationDestination{},
+		func(m map[string]*schema.Schema) map[string]*schema.Schema {
+			delete(m, "id")
+			config := m["config"].Elem.(*schema.Resource).Schema
+			eoof := []string{"config.0.slack", "config.0.pagerduty", "config.0.generic_webhook"}
+			for _, field := range []string{"slack", "pagerduty", "generic_webhook"} {
+				// backend doesn't allow changing the type of destination
+				config[field].ForceNew = true
+				config[field].ExactlyOneOf = eoof
+			}
+			return m
+		})
+	return common.Resource{
+		Schema: s,
+		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var nd NotificationDestination
+			if err := common.DataToStructPointer(d, s, &nd); err != nil {
+				return err
+			}
+			if err := NewNotificationDestinationsAPI(ctx, c).Create(&nd); err != nil {
+				return err
+			}
+			d.SetId(nd.ID)
+			return nil
+		},
+		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			nd, err := NewNotificationDestinationsAPI(ctx, c).Read(d.Id())
+			if err != nil {
+				return err
+			}
+			// configuration contains only secrets, that are not returned
+			// from the backend, so we keep whatever is in the state
+			d.Set("display_name", nd.DisplayName)
+			d.Set("destination_type", nd.DestinationType)
+			return nil
+		},
+		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var nd NotificationDestination
+			if err := common.DataToStructPointer(d, s, &nd); err != nil {
+				return err
+			}
+			// type is derived from configuration and cannot be sent back
+			nd.DestinationType = ""
+			return NewNotificationDestinationsAPI(ctx, c).Update(d.Id(), nd)
+		},
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			return NewNotificationDestinationsAPI(ctx, c).Delete(d.Id())
+		},
+	}.ToResource()
+}
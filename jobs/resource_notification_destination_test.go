@@ -0,0 +1,170 @@
+package jobs
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceNotificationDestinationCreate(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/notification-destinations",
+				ExpectedRequest: NotificationDestination{
+					DisplayName: "On-call",
+					Config: &NotificationDestinationConfig{
+						Slack: &SlackConfig{
+							URL: "https://hooks.slack.com/services/abc",
+						},
+					},
+				},
+				Response: NotificationDestination{
+					ID:              "abc",
+					DisplayName:     "On-call",
+					DestinationType: "SLACK",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/notification-destinations/abc",
+				Response: NotificationDestination{
+					ID:              "abc",
+					DisplayName:     "On-call",
+					DestinationType: "SLACK",
+				},
+			},
+		},
+		Resource: ResourceNotificationDestination(),
+		Create:   true,
+		HCL: `
+		display_name = "On-call"
+		config {
+			slack {
+				url = "https://hooks.slack.com/services/abc"
+			}
+		}`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, "SLACK", d.Get("destination_type"))
+	assert.Equal(t, "https://hooks.slack.com/services/abc", d.Get("config.0.slack.0.url"))
+}
+
+func TestResourceNotificationDestinationCreate_ExactlyOne(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceNotificationDestination(),
+		Create:   true,
+		HCL: `
+		display_name = "On-call"
+		config {
+			slack {
+				url = "https://hooks.slack.com/services/abc"
+			}
+			pagerduty {
+				integration_key = "xyz"
+			}
+		}`,
+	}.ExpectError(t, "invalid config supplied. "+
+		"[config.#.generic_webhook] Invalid combination of arguments. "+
+		"[config.#.pagerduty] Invalid combination of arguments. "+
+		"[config.#.slack] Invalid combination of arguments")
+}
+
+func TestResourceNotificationDestinationRead(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/notification-destinations/abc",
+				Response: NotificationDestination{
+					ID:              "abc",
+					DisplayName:     "PagerDuty",
+					DestinationType: "PAGERDUTY",
+				},
+			},
+		},
+		Resource: ResourceNotificationDestination(),
+		Read:     true,
+		New:      true,
+		ID:       "abc",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "PagerDuty", d.Get("display_name"))
+	assert.Equal(t, "PAGERDUTY", d.Get("destination_type"))
+}
+
+func TestResourceNotificationDestinationUpdate(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/notification-destinations/abc",
+				ExpectedRequest: NotificationDestination{
+					DisplayName: "Webhook",
+					Config: &NotificationDestinationConfig{
+						GenericWebhook: &GenericWebhookConfig{
+							URL:      "https://example.com/hook",
+							Username: "alerts",
+							Password: "secret",
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/notification-destinations/abc",
+				Response: NotificationDestination{
+					ID:              "abc",
+					DisplayName:     "Webhook",
+					DestinationType: "WEBHOOK",
+				},
+			},
+		},
+		Resource: ResourceNotificationDestination(),
+		Update:   true,
+		ID:       "abc",
+		InstanceState: map[string]string{
+			"display_name":                        "Old name",
+			"destination_type":                    "WEBHOOK",
+			"config.#":                            "1",
+			"config.0.generic_webhook.#":          "1",
+			"config.0.generic_webhook.0.url":      "https://example.com/hook",
+			"config.0.generic_webhook.0.username": "alerts",
+			"config.0.generic_webhook.0.password": "secret",
+		},
+		HCL: `
+		display_name = "Webhook"
+		config {
+			generic_webhook {
+				url = "https://example.com/hook"
+				username = "alerts"
+				password = "secret"
+			}
+		}`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "Webhook", d.Get("display_name"))
+}
+
+func TestResourceNotificationDestinationDelete(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "DELETE",
+				Resource: "/api/2.0/notification-destinations/abc",
+			},
+		},
+		Resource: ResourceNotificationDestination(),
+		Delete:   true,
+		ID:       "abc",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+}
+
+func TestResourceNotificationDestinationCornerCases(t *testing.T) {
+	qa.ResourceCornerCases(t, ResourceNotificationDestination())
+}
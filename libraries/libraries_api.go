@@ -45,33 +45,71 @@ func (a LibrariesAPI) ClusterStatus(clusterID string) (cls ClusterLibraryStatuse
 	return
 }
 
+// Policies for libraries, that failed to install on a running cluster
+const (
+	// OnFailureFail fails the apply and is the default policy
+	OnFailureFail = "fail"
+	// OnFailureWarn keeps failed library on the cluster and only logs a warning
+	OnFailureWarn = "warn"
+	// OnFailureUninstall removes failed library from the cluster and proceeds
+	OnFailureUninstall = "uninstall"
+)
+
 type Wait struct {
 	ClusterID string
 	Timeout   time.Duration
 	IsRunning bool
 	IsRefresh bool
+	// OnFailure contains failure policies by Library.String().
+	// Libraries without policy are treated as OnFailureFail
+	OnFailure map[string]string
+}
+
+func (wait Wait) onFailure(lib *Library) string {
+	if lib == nil {
+		return OnFailureFail
+	}
+	policy, ok := wait.OnFailure[lib.String()]
+	if !ok || policy == "" {
+		return OnFailureFail
+	}
+	return policy
+}
+
+// withoutToleratedFailures returns statuses without failed libraries, that should not fail the apply
+func (wait Wait) withoutToleratedFailures(cls ClusterLibraryStatuses) ClusterLibraryStatuses {
+	filtered := ClusterLibraryStatuses{ClusterID: cls.ClusterID}
+	for _, v := range cls.LibraryStatuses {
+		if v.Status == "FAILED" && wait.onFailure(v.Library) != OnFailureFail {
+			continue
+		}
+		filtered.LibraryStatuses = append(filtered.LibraryStatuses, v)
+	}
+	return filtered
 }
 
-func (a LibrariesAPI) UpdateLibraries(clusterID string, add, remove ClusterLibraryList, timeout time.Duration) error {
+// UpdateLibraries installs and uninstalls libraries on a running cluster and returns their statuses
+func (a LibrariesAPI) UpdateLibraries(clusterID string, add, remove ClusterLibraryList,
+	timeout time.Duration, onFailure map[string]string) (*ClusterLibraryStatuses, error) {
 	if len(remove.Libraries) > 0 {
 		err := a.Uninstall(remove)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 	if len(add.Libraries) > 0 {
 		err := a.Install(add)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
-	_, err := a.WaitForLibrariesInstalled(Wait{
+	return a.WaitForLibrariesInstalled(Wait{
 		ClusterID: clusterID,
 		Timeout:   timeout,
 		IsRunning: true,
 		IsRefresh: false,
+		OnFailure: onFailure,
 	})
-	return err
 }
 
 // clusterID string, timeout time.Duration, isActive bool, refresh bool
@@ -91,7 +129,7 @@ func (a LibrariesAPI) WaitForLibrariesInstalled(wait Wait) (result *ClusterLibra
 			result = &libsClusterStatus
 			return nil
 		}
-		retry, err := libsClusterStatus.IsRetryNeeded(wait.IsRefresh)
+		retry, err := wait.withoutToleratedFailures(libsClusterStatus).IsRetryNeeded(wait.IsRefresh)
 		if retry {
 			return resource.RetryableError(err)
 		}
@@ -113,13 +151,24 @@ func (a LibrariesAPI) WaitForLibrariesInstalled(wait Wait) (result *ClusterLibra
 		// cleanup libraries that failed to install
 		for _, v := range result.LibraryStatuses {
 			if v.Status == "FAILED" {
+				if wait.onFailure(v.Library) == OnFailureWarn {
+					log.Printf("[WARN] Library %s failed to install on %s: %s", v.Library,
+						wait.ClusterID, strings.Join(v.Messages, ", "))
+					installed = append(installed, v)
+					continue
+				}
 				log.Printf("[WARN] Removing failed library %s from %s", v.Library, wait.ClusterID)
 				cleanup.Libraries = append(cleanup.Libraries, *v.Library)
+				if wait.onFailure(v.Library) == OnFailureUninstall {
+					// keep failure details visible, even though library is removed
+					installed = append(installed, v)
+				}
 				continue
 			}
 			installed = append(installed, v)
 		}
 		// and result contains only the libraries that were successfully installed
+		// or the ones, that were removed by the uninstall policy
 		result.LibraryStatuses = installed
 		if len(cleanup.Libraries) > 0 {
 			err = a.Uninstall(cleanup)
@@ -241,6 +290,10 @@ func (cll *ClusterLibraryList) Diff(cls ClusterLibraryStatuses) (ClusterLibraryL
 	}
 	inState := map[string]Library{}
 	for _, status := range cls.LibraryStatuses {
+		if status.Status == "UNINSTALL_ON_RESTART" {
+			// library is already going away
+			continue
+		}
 		lib := *status.Library
 		inState[lib.String()] = lib
 	}
@@ -300,12 +353,26 @@ type ClusterLibraryStatuses struct {
 func (cls ClusterLibraryStatuses) ToLibraryList() ClusterLibraryList {
 	cll := ClusterLibraryList{ClusterID: cls.ClusterID}
 	for _, lib := range cls.LibraryStatuses {
+		if lib.Status == "UNINSTALL_ON_RESTART" {
+			// library won't be there after the next restart
+			continue
+		}
 		cll.Libraries = append(cll.Libraries, *lib.Library)
 	}
 	cll.Sort()
 	return cll
 }
 
+// IsRestartNeeded returns true if some libraries are removed only after cluster restart
+func (cls ClusterLibraryStatuses) IsRestartNeeded() bool {
+	for _, lib := range cls.LibraryStatuses {
+		if lib.Status == "UNINSTALL_ON_RESTART" {
+			return true
+		}
+	}
+	return false
+}
+
 // IsRetryNeeded returns first bool if there needs to be retry.
 // If there needs to be retry, error message will explain why.
 // If retry does not need to happen and error is not nil - it failed.
@@ -83,40 +83,119 @@ func TestWaitForLibrariesInstalled(t *testing.T) {
 	}, func(ctx context.Context, client *common.DatabricksClient) {
 		libs := NewLibrariesAPI(ctx, client)
 		_, err := libs.WaitForLibrariesInstalled(Wait{
-			"missing", 50 * time.Millisecond, true, false,
+			"missing", 50 * time.Millisecond, true, false, nil,
 		})
 		assert.EqualError(t, err, "missing")
 
 		_, err = libs.WaitForLibrariesInstalled(Wait{
-			"error", 50 * time.Millisecond, true, false,
+			"error", 50 * time.Millisecond, true, false, nil,
 		})
 		assert.EqualError(t, err, "internal error")
 
 		// cluster is not running
 		_, err = libs.WaitForLibrariesInstalled(Wait{
-			"still-installing", 50 * time.Millisecond, false, false,
+			"still-installing", 50 * time.Millisecond, false, false, nil,
 		})
 		assert.NoError(t, err)
 
 		// cluster is running
 		_, err = libs.WaitForLibrariesInstalled(Wait{
-			"still-installing", 50 * time.Millisecond, true, false,
+			"still-installing", 50 * time.Millisecond, true, false, nil,
 		})
 		assert.EqualError(t, err, "0 libraries are ready, but there are still 1 pending")
 
 		_, err = libs.WaitForLibrariesInstalled(Wait{
-			"failed-wheel", 50 * time.Millisecond, true, false,
+			"failed-wheel", 50 * time.Millisecond, true, false, nil,
 		})
 		assert.EqualError(t, err, "whl:b.whl failed: does not compute")
 
 		// uninstall b.whl and continue executing
 		_, err = libs.WaitForLibrariesInstalled(Wait{
-			"failed-wheel", 50 * time.Millisecond, true, true,
+			"failed-wheel", 50 * time.Millisecond, true, true, nil,
 		})
 		assert.NoError(t, err, "library should have been uninstalled and work proceeded")
 	})
 }
 
+func TestWaitForLibrariesInstalled_FailurePolicies(t *testing.T) {
+	failedWheel := ClusterLibraryStatuses{
+		ClusterID: "abc",
+		LibraryStatuses: []LibraryStatus{
+			{
+				Status:   "FAILED",
+				Messages: []string{"does not compute"},
+				Library: &Library{
+					Whl: "b.whl",
+				},
+			},
+			{
+				Status: "INSTALLED",
+				Library: &Library{
+					Jar: "a.jar",
+				},
+			},
+		},
+	}
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:       "GET",
+			Resource:     "/api/2.0/libraries/cluster-status?cluster_id=abc",
+			ReuseRequest: true,
+			Response:     failedWheel,
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/libraries/uninstall",
+			ExpectedRequest: ClusterLibraryList{
+				ClusterID: "abc",
+				Libraries: []Library{
+					{
+						Whl: "b.whl",
+					},
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		libs := NewLibrariesAPI(ctx, client)
+		// failed library is kept on cluster
+		result, err := libs.WaitForLibrariesInstalled(Wait{
+			ClusterID: "abc",
+			Timeout:   50 * time.Millisecond,
+			IsRunning: true,
+			OnFailure: map[string]string{
+				"whl:b.whl": OnFailureWarn,
+			},
+		})
+		require.NoError(t, err)
+		assert.Len(t, result.LibraryStatuses, 2)
+
+		// failed library is removed from cluster, but its status is kept
+		result, err = libs.WaitForLibrariesInstalled(Wait{
+			ClusterID: "abc",
+			Timeout:   50 * time.Millisecond,
+			IsRunning: true,
+			OnFailure: map[string]string{
+				"whl:b.whl": OnFailureUninstall,
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, result.LibraryStatuses, 2)
+		assert.Equal(t, "FAILED", result.LibraryStatuses[0].Status)
+		assert.Equal(t, []string{"does not compute"}, result.LibraryStatuses[0].Messages)
+
+		// explicit fail policy is the same as no policy
+		_, err = libs.WaitForLibrariesInstalled(Wait{
+			ClusterID: "abc",
+			Timeout:   50 * time.Millisecond,
+			IsRunning: true,
+			OnFailure: map[string]string{
+				"whl:b.whl": OnFailureFail,
+			},
+		})
+		assert.EqualError(t, err, "whl:b.whl failed: does not compute")
+	})
+}
+
 func TestClusterLibraryStatuses_UpdateLibraries(t *testing.T) {
 	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
 		{
@@ -148,7 +227,7 @@ func TestClusterLibraryStatuses_UpdateLibraries(t *testing.T) {
 		},
 	}, func(ctx context.Context, client *common.DatabricksClient) {
 		libsAPI := NewLibrariesAPI(ctx, client)
-		err := libsAPI.UpdateLibraries("abc", ClusterLibraryList{
+		_, err := libsAPI.UpdateLibraries("abc", ClusterLibraryList{
 			Libraries: []Library{
 				{
 					Jar: "add.jar",
@@ -160,7 +239,7 @@ func TestClusterLibraryStatuses_UpdateLibraries(t *testing.T) {
 					Jar: "remove.jar",
 				},
 			},
-		}, 1*time.Second)
+		}, 1*time.Second, nil)
 		assert.NoError(t, err)
 	})
 }
@@ -350,3 +429,39 @@ func TestNewLibraryFromInstanceState(t *testing.T) {
 		})
 	}
 }
+
+func TestClusterLibraryStatuses_UninstallOnRestart(t *testing.T) {
+	cls := ClusterLibraryStatuses{
+		ClusterID: "abc",
+		LibraryStatuses: []LibraryStatus{
+			{
+				Library: &Library{
+					Jar: "a",
+				},
+				Status: "INSTALLED",
+			},
+			{
+				Library: &Library{
+					Jar: "b",
+				},
+				Status: "UNINSTALL_ON_RESTART",
+			},
+		},
+	}
+	assert.True(t, cls.IsRestartNeeded())
+	cll := cls.ToLibraryList()
+	assert.Equal(t, "abc/jar:a", cll.String())
+
+	install, uninstall := (&ClusterLibraryList{
+		ClusterID: "abc",
+		Libraries: []Library{
+			{
+				Jar: "b",
+			},
+		},
+	}).Diff(cls)
+	assert.Equal(t, "abc/jar:b", install.String())
+	assert.Equal(t, "abc/jar:a", uninstall.String())
+
+	assert.False(t, ClusterLibraryStatuses{}.IsRestartNeeded())
+}
@@ -1,10 +1,13 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
+	"time"
 
+	"github.com/databrickslabs/terraform-provider-databricks/commands"
 	"github.com/databrickslabs/terraform-provider-databricks/common"
 	"github.com/databrickslabs/terraform-provider-databricks/exporter"
 	"github.com/databrickslabs/terraform-provider-databricks/provider"
@@ -18,7 +21,9 @@ func main() {
 		return
 	}
 	if len(os.Args) > 1 && os.Args[1] == "exporter" {
-		if err := exporter.Run(os.Args...); err != nil {
+		err := exporter.Run(os.Args...)
+		commands.DestroyContexts(context.Background())
+		if err != nil {
 			log.Printf("[ERROR] %s", err.Error())
 			os.Exit(1)
 		}
@@ -32,4 +37,8 @@ https://registry.terraform.io/providers/databrickslabs/databricks/latest/docs
 
 `, common.Version())
 	plugin.Serve(&plugin.ServeOpts{ProviderFunc: provider.DatabricksProvider})
+	// provider process is killed two seconds after Terraform asks it to stop
+	ctx, cancel := context.WithTimeout(context.Background(), 1500*time.Millisecond)
+	defer cancel()
+	commands.DestroyContexts(ctx)
 }
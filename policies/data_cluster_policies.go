@@ -0,0 +1,60 @@
+package policies
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DataSourceClusterPolicies returns IDs of cluster policies, optionally filtered by name
+func DataSourceClusterPolicies() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			policies, err := NewClusterPoliciesAPI(ctx, m).List()
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			ids := schema.NewSet(schema.HashString, []interface{}{})
+			names := map[string]interface{}{}
+			nameContains := strings.ToLower(d.Get("policy_name_contains").(string))
+			for _, v := range policies {
+				if nameContains != "" && !strings.Contains(strings.ToLower(v.Name), nameContains) {
+					continue
+				}
+				if id, ok := names[v.Name]; ok {
+					// names are expected to be unique, so duplicates are not silently dropped from the map
+					return diag.Errorf("there are multiple cluster policies named %s: %s and %s",
+						v.Name, id, v.PolicyID)
+				}
+				ids.Add(v.PolicyID)
+				names[v.Name] = v.PolicyID
+			}
+			d.Set("ids", ids)
+			d.Set("policies", names)
+			d.SetId("_")
+			return nil
+		},
+		Schema: map[string]*schema.Schema{
+			"ids": {
+				Computed: true,
+				Type:     schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"policies": {
+				Computed: true,
+				Type:     schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"policy_name_contains": {
+				Optional: true,
+				Type:     schema.TypeString,
+			},
+		},
+	}
+}
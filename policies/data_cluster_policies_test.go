@@ -0,0 +1,74 @@
+package policies
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataSourceClusterPolicies(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/policies/clusters/list",
+				Response: testPolicyList,
+			},
+		},
+		Resource:    DataSourceClusterPolicies(),
+		NonWritable: true,
+		Read:        true,
+		New:         true,
+		ID:          "_",
+		HCL:         `policy_name_contains = "team"`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, []interface{}{"def"}, d.Get("ids").(*schema.Set).List())
+	assert.Equal(t, map[string]interface{}{"Team Compute": "def"}, d.Get("policies"))
+}
+
+func TestDataSourceClusterPolicies_DuplicateNames(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/policies/clusters/list",
+				Response: ClusterPolicyList{
+					Policies: []ClusterPolicy{
+						{PolicyID: "abc", Name: "Team Compute"},
+						{PolicyID: "def", Name: "Team Compute"},
+					},
+				},
+			},
+		},
+		Resource:    DataSourceClusterPolicies(),
+		NonWritable: true,
+		Read:        true,
+		New:         true,
+		ID:          "_",
+	}.ExpectError(t, "there are multiple cluster policies named Team Compute: abc and def")
+}
+
+func TestDataSourceClusterPolicies_Error(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/policies/clusters/list",
+				Response: common.APIErrorBody{
+					ErrorCode: "INVALID_REQUEST",
+					Message:   "Internal error happened",
+				},
+				Status: 400,
+			},
+		},
+		Resource:    DataSourceClusterPolicies(),
+		NonWritable: true,
+		Read:        true,
+		New:         true,
+		ID:          "_",
+	}.ExpectError(t, "Internal error happened")
+}
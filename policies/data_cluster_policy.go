@@ -0,0 +1,66 @@
+package policies
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// DataSourceClusterPolicy returns information about cluster policy specified by name
+func DataSourceClusterPolicy() *schema.Resource {
+	type entity struct {
+		Name                            string `json:"name"`
+		Definition                      string `json:"definition,omitempty" tf:"computed"`
+		MaxClustersPerUser              int64  `json:"max_clusters_per_user,omitempty" tf:"computed"`
+		PolicyFamilyID                  string `json:"policy_family_id,omitempty" tf:"computed"`
+		PolicyFamilyDefinitionOverrides string `json:"policy_family_definition_overrides,omitempty" tf:"computed"`
+	}
+	s := common.StructToSchema(entity{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		s["name"].ValidateFunc = validation.StringIsNotEmpty
+		return s
+	})
+	return &schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			var this entity
+			err := common.DataToStructPointer(d, s, &this)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			policy, err := NewClusterPoliciesAPI(ctx, m).GetByName(this.Name)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId(policy.PolicyID)
+			this.Definition = policy.Definition
+			this.MaxClustersPerUser = policy.MaxClustersPerUser
+			this.PolicyFamilyID = policy.PolicyFamilyID
+			this.PolicyFamilyDefinitionOverrides = policy.PolicyFamilyDefinitionOverrides
+			err = common.StructToData(this, s, d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			return nil
+		},
+	}
+}
+
+// GetByName returns cluster policy with exactly the same name
+func (a ClusterPoliciesAPI) GetByName(name string) (policy ClusterPolicy, err error) {
+	policies, err := a.List()
+	if err != nil {
+		return
+	}
+	for _, p := range policies {
+		if p.Name == name {
+			return p, nil
+		}
+	}
+	err = fmt.Errorf("cluster policy '%s' wasn't found", name)
+	return
+}
@@ -0,0 +1,65 @@
+package policies
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+)
+
+var testPolicyList = ClusterPolicyList{
+	Policies: []ClusterPolicy{
+		{
+			PolicyID:   "abc",
+			Name:       "Personal Compute",
+			Definition: `{"num_workers": {"type": "fixed", "value": 0}}`,
+		},
+		{
+			PolicyID:           "def",
+			Name:               "Team Compute",
+			Definition:         `{"node_type_id": {"type": "fixed", "value": "i3.xlarge"}}`,
+			MaxClustersPerUser: 2,
+		},
+	},
+	TotalCount: 2,
+}
+
+func TestDataSourceClusterPolicy(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/policies/clusters/list",
+				Response: testPolicyList,
+			},
+		},
+		Resource:    DataSourceClusterPolicy(),
+		NonWritable: true,
+		Read:        true,
+		New:         true,
+		ID:          "_",
+		HCL:         `name = "Team Compute"`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "def", d.Id())
+	assert.Equal(t, `{"node_type_id": {"type": "fixed", "value": "i3.xlarge"}}`, d.Get("definition"))
+	assert.Equal(t, 2, d.Get("max_clusters_per_user"))
+}
+
+func TestDataSourceClusterPolicy_NotFound(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/policies/clusters/list",
+				Response: testPolicyList,
+			},
+		},
+		Resource:    DataSourceClusterPolicy(),
+		NonWritable: true,
+		Read:        true,
+		New:         true,
+		ID:          "_",
+		HCL:         `name = "team compute"`,
+	}.ExpectError(t, "cluster policy 'team compute' wasn't found")
+}
@@ -0,0 +1,423 @@
+package policies
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Types of policy elements in Databricks Policy Definition Language
+const (
+	PolicyTypeFixed     = "fixed"
+	PolicyTypeForbidden = "forbidden"
+	PolicyTypeAllowlist = "allowlist"
+	PolicyTypeBlocklist = "blocklist"
+	PolicyTypeRegex     = "regex"
+	PolicyTypeRange     = "range"
+	PolicyTypeUnlimited = "unlimited"
+)
+
+// virtual attributes, that cannot be derived from cluster definition during plan
+var unverifiablePolicyAttributes = map[string]bool{
+	"dbus_per_hour": true,
+}
+
+// policy attributes, that are maps of strings in cluster definition
+var stringMapPolicyPrefixes = []string{"spark_conf.", "spark_env_vars.", "custom_tags."}
+
+// PolicyElement is a single attribute rule of a cluster policy definition
+type PolicyElement struct {
+	Type         string        `json:"type"`
+	Value        interface{}   `json:"value,omitempty"`
+	Values       []interface{} `json:"values,omitempty"`
+	MinValue     *float64      `json:"minValue,omitempty"`
+	MaxValue     *float64      `json:"maxValue,omitempty"`
+	Pattern      string        `json:"pattern,omitempty"`
+	DefaultValue interface{}   `json:"defaultValue,omitempty"`
+	Hidden       bool          `json:"hidden,omitempty"`
+	IsOptional   bool          `json:"isOptional,omitempty"`
+}
+
+// PolicyDefinition maps attribute paths, like `spark_conf.foo` or `init_scripts.*.dbfs.destination`,
+// to policy elements
+type PolicyDefinition map[string]PolicyElement
+
+// PolicyViolation describes why attribute doesn't comply with a policy
+type PolicyViolation struct {
+	Path    string
+	Message string
+}
+
+func (pv PolicyViolation) String() string {
+	return fmt.Sprintf("%s %s", pv.Path, pv.Message)
+}
+
+// ParsePolicyDefinition parses JSON document into policy definition
+func ParsePolicyDefinition(definition string) (pd PolicyDefinition, err error) {
+	err = json.Unmarshal([]byte(definition), &pd)
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy definition: %w", err)
+	}
+	for path, elem := range pd {
+		switch elem.Type {
+		case PolicyTypeFixed, PolicyTypeForbidden, PolicyTypeAllowlist,
+			PolicyTypeBlocklist, PolicyTypeRange, PolicyTypeUnlimited:
+		case PolicyTypeRegex:
+			if _, err = regexp.Compile(elem.Pattern); err != nil {
+				return nil, fmt.Errorf("invalid pattern for %s: %w", path, err)
+			}
+		default:
+			return nil, fmt.Errorf("unknown policy type for %s: %s", path, elem.Type)
+		}
+	}
+	return pd, nil
+}
+
+// FlattenPolicyAttributes converts JSON-serializable entity into a map of
+// attribute paths in the same notation as used by policy definitions
+func FlattenPolicyAttributes(entity interface{}) (map[string]interface{}, error) {
+	raw, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+	var tree interface{}
+	if err = json.Unmarshal(raw, &tree); err != nil {
+		return nil, err
+	}
+	attributes := map[string]interface{}{}
+	flattenPolicyAttributes("", tree, attributes)
+	return attributes, nil
+}
+
+func flattenPolicyAttributes(prefix string, v interface{}, attributes map[string]interface{}) {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		for k, nested := range x {
+			flattenPolicyAttributes(prefix+k+".", nested, attributes)
+		}
+	case []interface{}:
+		for i, nested := range x {
+			flattenPolicyAttributes(fmt.Sprintf("%s%d.", prefix, i), nested, attributes)
+		}
+	case nil:
+		return
+	default:
+		attributes[strings.TrimSuffix(prefix, ".")] = x
+	}
+}
+
+// policyValueString normalizes values, so that `true` and `"true"` are the same
+func policyValueString(v interface{}) string {
+	switch x := v.(type) {
+	case string:
+		return x
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(x)
+	default:
+		return fmt.Sprint(x)
+	}
+}
+
+// matchingAttributes returns all attribute paths covered by the policy path,
+// where `*` matches any array index. When nested is true, attributes nested
+// under the policy path are matched as well.
+func matchingAttributes(path string, attributes map[string]interface{}, nested bool) (matches []string) {
+	pattern := strings.Split(path, ".")
+	for attr := range attributes {
+		parts := strings.Split(attr, ".")
+		if len(parts) < len(pattern) || (!nested && len(parts) != len(pattern)) {
+			continue
+		}
+		matched := true
+		for i, p := range pattern {
+			if p != "*" && p != parts[i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			matches = append(matches, attr)
+		}
+	}
+	sort.Strings(matches)
+	return
+}
+
+// Validate checks flattened attributes against the policy definition. Attributes,
+// for which isKnown returns false, are not yet known during plan and are skipped.
+func (pd PolicyDefinition) Validate(attributes map[string]interface{},
+	isKnown func(path string) bool) (violations []PolicyViolation) {
+	paths := []string{}
+	for path := range pd {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		if unverifiablePolicyAttributes[path] || !isKnown(path) {
+			continue
+		}
+		elem := pd[path]
+		if elem.Type == PolicyTypeForbidden {
+			// forbidden applies to nested attributes as well, like `cluster_log_conf`
+			if len(matchingAttributes(path, attributes, true)) > 0 {
+				violations = append(violations, PolicyViolation{path, "is forbidden by policy"})
+			}
+			continue
+		}
+		matches := matchingAttributes(path, attributes, false)
+		if len(matches) == 0 {
+			if elem.isRequired() && !strings.Contains(path, "*") {
+				violations = append(violations, PolicyViolation{path, "is required by policy"})
+			}
+			continue
+		}
+		for _, attr := range matches {
+			if msg := elem.check(attributes[attr]); msg != "" {
+				violations = append(violations, PolicyViolation{attr, msg})
+			}
+		}
+	}
+	return
+}
+
+// fixed values are set by the platform and limiting elements make attribute
+// required, unless it's optional or has the default value
+func (elem PolicyElement) isRequired() bool {
+	switch elem.Type {
+	case PolicyTypeFixed, PolicyTypeForbidden:
+		return false
+	}
+	return !elem.IsOptional && elem.DefaultValue == nil
+}
+
+// check returns violation message or empty string, if value complies with policy element
+func (elem PolicyElement) check(v interface{}) string {
+	value := policyValueString(v)
+	switch elem.Type {
+	case PolicyTypeFixed:
+		expected := policyValueString(elem.Value)
+		if value != expected {
+			return fmt.Sprintf("must be %s, but is %s", expected, value)
+		}
+	case PolicyTypeAllowlist:
+		allowed := []string{}
+		for _, a := range elem.Values {
+			if policyValueString(a) == value {
+				return ""
+			}
+			allowed = append(allowed, policyValueString(a))
+		}
+		return fmt.Sprintf("must be one of %s, but is %s", strings.Join(allowed, ", "), value)
+	case PolicyTypeBlocklist:
+		for _, b := range elem.Values {
+			if policyValueString(b) == value {
+				return fmt.Sprintf("cannot be %s", value)
+			}
+		}
+	case PolicyTypeRegex:
+		// patterns are matched against the whole value
+		re, err := regexp.Compile("^(?:" + elem.Pattern + ")$")
+		if err != nil {
+			return fmt.Sprintf("has invalid pattern in policy: %s", err)
+		}
+		if !re.MatchString(value) {
+			return fmt.Sprintf("must match %s, but is %s", elem.Pattern, value)
+		}
+	case PolicyTypeRange:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Sprintf("must be a number, but is %s", value)
+		}
+		if elem.MinValue != nil && number < *elem.MinValue {
+			return fmt.Sprintf("must be at least %s, but is %s",
+				policyValueString(*elem.MinValue), value)
+		}
+		if elem.MaxValue != nil && number > *elem.MaxValue {
+			return fmt.Sprintf("must be at most %s, but is %s",
+				policyValueString(*elem.MaxValue), value)
+		}
+	}
+	return ""
+}
+
+// policyPathSegments splits attribute path into JSON keys, keeping keys of string maps intact
+func policyPathSegments(path string) []string {
+	for _, prefix := range stringMapPolicyPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return []string{strings.TrimSuffix(prefix, "."), strings.TrimPrefix(path, prefix)}
+		}
+	}
+	return strings.Split(path, ".")
+}
+
+// presetValue returns value, that platform sets for the attribute, if it's not specified
+func (elem PolicyElement) presetValue(path string) interface{} {
+	v := elem.DefaultValue
+	if elem.Type == PolicyTypeFixed {
+		v = elem.Value
+	}
+	if v == nil {
+		return nil
+	}
+	for _, prefix := range stringMapPolicyPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return policyValueString(v)
+		}
+	}
+	return v
+}
+
+// PresetPaths returns attribute paths, that have fixed or default values in the policy
+func (pd PolicyDefinition) PresetPaths() (paths []string) {
+	for path, elem := range pd {
+		if strings.Contains(path, "*") || elem.presetValue(path) == nil {
+			continue
+		}
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return
+}
+
+// modifyJSONTree round-trips entity pointer through generic JSON tree
+func modifyJSONTree(entity interface{}, modify func(tree map[string]interface{})) error {
+	raw, err := json.Marshal(entity)
+	if err != nil {
+		return err
+	}
+	tree := map[string]interface{}{}
+	if err = json.Unmarshal(raw, &tree); err != nil {
+		return err
+	}
+	modify(tree)
+	raw, err = json.Marshal(tree)
+	if err != nil {
+		return err
+	}
+	// removed keys must not keep their previous values
+	rv := reflect.ValueOf(entity).Elem()
+	rv.Set(reflect.Zero(rv.Type()))
+	return json.Unmarshal(raw, entity)
+}
+
+// ApplyDefaults sets fixed and default values from the policy to all attributes,
+// that are not specified in entity, which has to be a pointer to a JSON-serializable struct
+func (pd PolicyDefinition) ApplyDefaults(entity interface{}) error {
+	return modifyJSONTree(entity, func(tree map[string]interface{}) {
+		for _, path := range pd.PresetPaths() {
+			segments := policyPathSegments(path)
+			node := tree
+			for _, key := range segments[:len(segments)-1] {
+				next, ok := node[key]
+				if !ok {
+					next = map[string]interface{}{}
+					node[key] = next
+				}
+				node, ok = next.(map[string]interface{})
+				if !ok {
+					// arrays are not created from policies
+					break
+				}
+			}
+			if node == nil {
+				continue
+			}
+			last := segments[len(segments)-1]
+			if _, ok := node[last]; !ok {
+				node[last] = typedPresetValue(reflect.TypeOf(entity), segments,
+					pd[path].presetValue(path))
+			}
+		}
+	})
+}
+
+// typedPresetValue converts string preset to the type of the field at the JSON path,
+// because policy values, that are written as strings, may be set to numeric or boolean fields
+func typedPresetValue(t reflect.Type, segments []string, v interface{}) interface{} {
+	s, ok := v.(string)
+	if !ok {
+		return v
+	}
+	switch jsonFieldKind(t, segments) {
+	case reflect.Bool:
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Float32, reflect.Float64:
+		if number, err := strconv.ParseFloat(s, 64); err == nil {
+			return number
+		}
+	}
+	return v
+}
+
+// jsonFieldKind returns kind of the value at the JSON path within the given type
+// or reflect.Invalid, if there's no such path
+func jsonFieldKind(t reflect.Type, segments []string) reflect.Kind {
+	for _, key := range segments {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		switch t.Kind() {
+		case reflect.Map:
+			t = t.Elem()
+		case reflect.Struct:
+			found := false
+			for i := 0; i < t.NumField(); i++ {
+				field := t.Field(i)
+				if strings.Split(field.Tag.Get("json"), ",")[0] == key {
+					t = field.Type
+					found = true
+					break
+				}
+			}
+			if !found {
+				return reflect.Invalid
+			}
+		default:
+			return reflect.Invalid
+		}
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind()
+}
+
+// RemovePresets removes attributes, that have fixed or default values in the policy,
+// from entity, unless keep returns true for them. This way values set by the
+// platform are not reported as drift.
+func (pd PolicyDefinition) RemovePresets(entity interface{}, keep func(path string) bool) error {
+	return modifyJSONTree(entity, func(tree map[string]interface{}) {
+		for _, path := range pd.PresetPaths() {
+			if keep(path) {
+				continue
+			}
+			removeJSONPath(tree, policyPathSegments(path))
+		}
+	})
+}
+
+// removeJSONPath deletes the value and all parent objects, that became empty
+func removeJSONPath(node map[string]interface{}, segments []string) {
+	key := segments[0]
+	if len(segments) == 1 {
+		delete(node, key)
+		return
+	}
+	next, ok := node[key].(map[string]interface{})
+	if !ok {
+		return
+	}
+	removeJSONPath(next, segments[1:])
+	if len(next) == 0 {
+		delete(node, key)
+	}
+}
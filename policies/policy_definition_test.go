@@ -0,0 +1,224 @@
+package policies
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func allKnown(string) bool {
+	return true
+}
+
+func TestParsePolicyDefinition(t *testing.T) {
+	pd, err := ParsePolicyDefinition(`{
+		"spark_version": {"type": "regex", "pattern": "7\\.[0-9]+\\.x-scala.*"},
+		"autotermination_minutes": {"type": "range", "maxValue": 120, "defaultValue": 60}
+	}`)
+	require.NoError(t, err)
+	assert.Equal(t, PolicyTypeRegex, pd["spark_version"].Type)
+	assert.Equal(t, float64(120), *pd["autotermination_minutes"].MaxValue)
+	assert.Nil(t, pd["autotermination_minutes"].MinValue)
+	assert.Equal(t, float64(60), pd["autotermination_minutes"].DefaultValue)
+}
+
+func TestParsePolicyDefinition_Errors(t *testing.T) {
+	_, err := ParsePolicyDefinition(`{"a": {"type": "whatever"}}`)
+	assert.EqualError(t, err, "unknown policy type for a: whatever")
+
+	_, err = ParsePolicyDefinition(`{"a": {"type": "regex", "pattern": "("}}`)
+	assert.EqualError(t, err, "invalid pattern for a: error parsing regexp: "+
+		"missing closing ): `(`")
+
+	_, err = ParsePolicyDefinition(`[]`)
+	assert.EqualError(t, err, "invalid policy definition: json: cannot unmarshal "+
+		"array into Go value of type policies.PolicyDefinition")
+}
+
+func TestFlattenPolicyAttributes(t *testing.T) {
+	attributes, err := FlattenPolicyAttributes(map[string]interface{}{
+		"spark_version": "7.3.x-scala2.12",
+		"num_workers":   2,
+		"spark_conf": map[string]string{
+			"spark.databricks.cluster.profile": "serverless",
+		},
+		"init_scripts": []interface{}{
+			map[string]interface{}{
+				"dbfs": map[string]string{
+					"destination": "dbfs:/init.sh",
+				},
+			},
+		},
+		"cluster_log_conf": nil,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"spark_version": "7.3.x-scala2.12",
+		"num_workers":   float64(2),
+		"spark_conf.spark.databricks.cluster.profile": "serverless",
+		"init_scripts.0.dbfs.destination":             "dbfs:/init.sh",
+	}, attributes)
+}
+
+func TestPolicyDefinitionValidate(t *testing.T) {
+	pd, err := ParsePolicyDefinition(`{
+		"spark_conf.spark.databricks.cluster.profile": {"type": "fixed", "value": "serverless"},
+		"enable_elastic_disk": {"type": "fixed", "value": true},
+		"instance_pool_id": {"type": "forbidden"},
+		"cluster_log_conf": {"type": "forbidden"},
+		"node_type_id": {"type": "allowlist", "values": ["i3.xlarge", "i3.2xlarge"]},
+		"driver_node_type_id": {"type": "blocklist", "values": ["i3.16xlarge"]},
+		"spark_version": {"type": "regex", "pattern": "7\\.[0-9]+\\.x-scala.*"},
+		"autotermination_minutes": {"type": "range", "minValue": 10, "maxValue": 120},
+		"num_workers": {"type": "range", "maxValue": 10, "defaultValue": 1},
+		"custom_tags.team": {"type": "unlimited"},
+		"custom_tags.cost_center": {"type": "unlimited", "isOptional": true},
+		"init_scripts.*.dbfs.destination": {"type": "regex", "pattern": "dbfs:/init/.*"},
+		"ssh_public_keys.*": {"type": "forbidden"},
+		"dbus_per_hour": {"type": "range", "maxValue": 10}
+	}`)
+	require.NoError(t, err)
+
+	violations := pd.Validate(map[string]interface{}{
+		"spark_conf.spark.databricks.cluster.profile": "serverless",
+		"enable_elastic_disk":                         "true",
+		"node_type_id":                                "i3.xlarge",
+		"driver_node_type_id":                         "i3.2xlarge",
+		"spark_version":                               "7.3.x-scala2.12",
+		"autotermination_minutes":                     float64(60),
+		"custom_tags.team":                            "data",
+		"init_scripts.0.dbfs.destination":             "dbfs:/init/a.sh",
+	}, allKnown)
+	assert.Len(t, violations, 0)
+
+	violations = pd.Validate(map[string]interface{}{
+		"spark_conf.spark.databricks.cluster.profile": "singleNode",
+		"instance_pool_id":                  "abc",
+		"cluster_log_conf.dbfs.destination": "dbfs:/logs",
+		"node_type_id":                      "m4.large",
+		"driver_node_type_id":               "i3.16xlarge",
+		"spark_version":                     "6.4.x-scala2.11",
+		"autotermination_minutes":           float64(0),
+		"num_workers":                       float64(100),
+		"init_scripts.0.dbfs.destination":   "dbfs:/init/a.sh",
+		"init_scripts.1.dbfs.destination":   "dbfs:/other.sh",
+		"ssh_public_keys.0":                 "ssh-rsa AAA",
+	}, allKnown)
+	assert.Equal(t, []string{
+		"autotermination_minutes must be at least 10, but is 0",
+		"cluster_log_conf is forbidden by policy",
+		"custom_tags.team is required by policy",
+		"driver_node_type_id cannot be i3.16xlarge",
+		"init_scripts.1.dbfs.destination must match dbfs:/init/.*, but is dbfs:/other.sh",
+		"instance_pool_id is forbidden by policy",
+		"node_type_id must be one of i3.xlarge, i3.2xlarge, but is m4.large",
+		"num_workers must be at most 10, but is 100",
+		"spark_conf.spark.databricks.cluster.profile must be serverless, but is singleNode",
+		"spark_version must match 7\\.[0-9]+\\.x-scala.*, but is 6.4.x-scala2.11",
+		"ssh_public_keys.* is forbidden by policy",
+	}, violationStrings(violations))
+}
+
+func TestPolicyDefinitionValidate_UnknownAttributes(t *testing.T) {
+	pd, err := ParsePolicyDefinition(`{
+		"node_type_id": {"type": "allowlist", "values": ["i3.xlarge"]},
+		"spark_version": {"type": "fixed", "value": "7.3.x-scala2.12"}
+	}`)
+	require.NoError(t, err)
+	violations := pd.Validate(map[string]interface{}{
+		"spark_version": "6.4.x-scala2.11",
+	}, func(path string) bool {
+		return path != "node_type_id"
+	})
+	assert.Equal(t, []string{
+		"spark_version must be 7.3.x-scala2.12, but is 6.4.x-scala2.11",
+	}, violationStrings(violations))
+}
+
+func violationStrings(violations []PolicyViolation) (res []string) {
+	for _, v := range violations {
+		res = append(res, v.String())
+	}
+	return
+}
+
+type presetsEntity struct {
+	Name       string            `json:"name,omitempty"`
+	Minutes    int32             `json:"minutes,omitempty"`
+	Enabled    bool              `json:"enabled,omitempty"`
+	CustomTags map[string]string `json:"custom_tags,omitempty"`
+	Nested     *struct {
+		Zone string `json:"zone,omitempty"`
+	} `json:"nested,omitempty"`
+}
+
+func TestPolicyDefinitionApplyDefaults(t *testing.T) {
+	pd, err := ParsePolicyDefinition(`{
+		"name": {"type": "unlimited", "defaultValue": "default"},
+		"minutes": {"type": "range", "maxValue": 60, "defaultValue": 30},
+		"enabled": {"type": "fixed", "value": true},
+		"custom_tags.cost.center": {"type": "fixed", "value": 42},
+		"nested.zone": {"type": "allowlist", "values": ["a", "b"], "defaultValue": "a"},
+		"items.*.name": {"type": "fixed", "value": "x"},
+		"forbidden": {"type": "forbidden"}
+	}`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"custom_tags.cost.center", "enabled",
+		"minutes", "name", "nested.zone"}, pd.PresetPaths())
+
+	entity := presetsEntity{Name: "mine"}
+	err = pd.ApplyDefaults(&entity)
+	require.NoError(t, err)
+	assert.Equal(t, "mine", entity.Name)
+	assert.Equal(t, int32(30), entity.Minutes)
+	assert.True(t, entity.Enabled)
+	assert.Equal(t, map[string]string{"cost.center": "42"}, entity.CustomTags)
+	assert.Equal(t, "a", entity.Nested.Zone)
+}
+
+func TestPolicyDefinitionApplyDefaults_StringValues(t *testing.T) {
+	pd, err := ParsePolicyDefinition(`{
+		"name": {"type": "fixed", "value": "20"},
+		"minutes": {"type": "fixed", "value": "20"},
+		"enabled": {"type": "fixed", "value": "true"},
+		"custom_tags.Team": {"type": "fixed", "value": "1"}
+	}`)
+	require.NoError(t, err)
+	entity := presetsEntity{}
+	err = pd.ApplyDefaults(&entity)
+	require.NoError(t, err)
+	assert.Equal(t, "20", entity.Name)
+	assert.Equal(t, int32(20), entity.Minutes)
+	assert.True(t, entity.Enabled)
+	assert.Equal(t, map[string]string{"Team": "1"}, entity.CustomTags)
+}
+
+func TestPolicyDefinitionRemovePresets(t *testing.T) {
+	pd, err := ParsePolicyDefinition(`{
+		"name": {"type": "unlimited", "defaultValue": "default"},
+		"minutes": {"type": "range", "maxValue": 60, "defaultValue": 30},
+		"custom_tags.cost.center": {"type": "fixed", "value": 42},
+		"nested.zone": {"type": "allowlist", "values": ["a", "b"], "defaultValue": "a"}
+	}`)
+	require.NoError(t, err)
+	entity := presetsEntity{
+		Name:    "default",
+		Minutes: 30,
+		CustomTags: map[string]string{
+			"cost.center": "42",
+			"Team":        "data",
+		},
+		Nested: &struct {
+			Zone string `json:"zone,omitempty"`
+		}{"a"},
+	}
+	err = pd.RemovePresets(&entity, func(path string) bool {
+		return path == "minutes"
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "", entity.Name)
+	assert.Equal(t, int32(30), entity.Minutes)
+	assert.Equal(t, map[string]string{"Team": "data"}, entity.CustomTags)
+	assert.Nil(t, entity.Nested)
+}
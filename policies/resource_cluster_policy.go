@@ -2,6 +2,11 @@ package policies
 
 import (
 	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/databrickslabs/terraform-provider-databricks/common"
 
@@ -11,10 +16,19 @@ import (
 
 // ClusterPolicy defines cluster policy
 type ClusterPolicy struct {
-	PolicyID           string `json:"policy_id,omitempty"`
-	Name               string `json:"name"`
-	Definition         string `json:"definition"`
-	CreatedAtTimeStamp int64  `json:"created_at_timestamp"`
+	PolicyID                        string `json:"policy_id,omitempty"`
+	Name                            string `json:"name"`
+	Definition                      string `json:"definition,omitempty"`
+	CreatedAtTimeStamp              int64  `json:"created_at_timestamp,omitempty"`
+	MaxClustersPerUser              int64  `json:"max_clusters_per_user,omitempty"`
+	PolicyFamilyID                  string `json:"policy_family_id,omitempty"`
+	PolicyFamilyDefinitionOverrides string `json:"policy_family_definition_overrides,omitempty"`
+}
+
+// ClusterPolicyList is the response of list API
+type ClusterPolicyList struct {
+	Policies   []ClusterPolicy `json:"policies"`
+	TotalCount int32           `json:"total_count,omitempty"`
 }
 
 // ClusterPolicyCreate is the endity used for request
@@ -64,18 +78,179 @@ func (a ClusterPoliciesAPI) Get(policyID string) (policy ClusterPolicy, err erro
 	return
 }
 
+// List returns all cluster policies, visible to the current user
+func (a ClusterPoliciesAPI) List() ([]ClusterPolicy, error) {
+	var policyList ClusterPolicyList
+	err := a.client.Get(a.context, "/policies/clusters/list", nil, &policyList)
+	return policyList.Policies, err
+}
+
 // Delete removes cluster policy
 func (a ClusterPoliciesAPI) Delete(policyID string) error {
 	return a.client.Post(a.context, "/policies/clusters/delete", policyIDWrapper{policyID}, nil)
 }
 
+// typedPolicyValue converts HCL string to number, when policy type requires it,
+// and keeps the configured string otherwise
+func typedPolicyValue(path, policyType, value string) interface{} {
+	if policyType != PolicyTypeRange {
+		return value
+	}
+	for _, prefix := range stringMapPolicyPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return value
+		}
+	}
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return value
+	}
+	return number
+}
+
+// configuredLimits returns a function, that tells if min_value or max_value is explicitly
+// set for the rule, so that zero limits are not mistaken for the absent ones
+func configuredLimits(d *schema.ResourceData) func(path, key string) bool {
+	configured := map[string]bool{}
+	rules := d.GetRawConfig()
+	if !rules.IsNull() && rules.IsKnown() {
+		rules = rules.GetAttr("rule")
+	}
+	if rules.IsNull() || !rules.IsKnown() {
+		// configuration is not available, so zero means that the limit is not set
+		return func(path, key string) bool {
+			return false
+		}
+	}
+	for it := rules.ElementIterator(); it.Next(); {
+		_, rule := it.Element()
+		if rule.IsNull() || !rule.IsKnown() {
+			continue
+		}
+		path := rule.GetAttr("path")
+		if path.IsNull() || !path.IsKnown() {
+			continue
+		}
+		for _, key := range []string{"min_value", "max_value"} {
+			if !rule.GetAttr(key).IsNull() {
+				configured[path.AsString()+"/"+key] = true
+			}
+		}
+	}
+	return func(path, key string) bool {
+		return configured[path+"/"+key]
+	}
+}
+
+// rulesToDefinition converts `rule` blocks into policy definition JSON.
+// Zero limits are sent only if isConfigured returns true for them.
+func rulesToDefinition(rules []interface{}, isConfigured func(path, key string) bool) (string, error) {
+	pd := PolicyDefinition{}
+	for _, r := range rules {
+		rule := r.(map[string]interface{})
+		path := rule["path"].(string)
+		elem := PolicyElement{
+			Type:       rule["type"].(string),
+			Pattern:    rule["pattern"].(string),
+			Hidden:     rule["hidden"].(bool),
+			IsOptional: rule["is_optional"].(bool),
+		}
+		if v := rule["value"].(string); v != "" {
+			elem.Value = typedPolicyValue(path, elem.Type, v)
+		}
+		if v := rule["default_value"].(string); v != "" {
+			elem.DefaultValue = typedPolicyValue(path, elem.Type, v)
+		}
+		for _, v := range rule["values"].([]interface{}) {
+			elem.Values = append(elem.Values, typedPolicyValue(path, elem.Type, v.(string)))
+		}
+		if v := rule["min_value"].(float64); v != 0 || isConfigured(path, "min_value") {
+			elem.MinValue = &v
+		}
+		if v := rule["max_value"].(float64); v != 0 || isConfigured(path, "max_value") {
+			elem.MaxValue = &v
+		}
+		pd[path] = elem
+	}
+	definition, err := json.Marshal(pd)
+	return string(definition), err
+}
+
+// definitionToRules converts policy definition JSON into `rule` blocks
+func definitionToRules(definition string) ([]interface{}, error) {
+	pd, err := ParsePolicyDefinition(definition)
+	if err != nil {
+		return nil, err
+	}
+	paths := []string{}
+	for path := range pd {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	rules := []interface{}{}
+	for _, path := range paths {
+		elem := pd[path]
+		rule := map[string]interface{}{
+			"path":        path,
+			"type":        elem.Type,
+			"pattern":     elem.Pattern,
+			"hidden":      elem.Hidden,
+			"is_optional": elem.IsOptional,
+		}
+		if elem.Value != nil {
+			rule["value"] = policyValueString(elem.Value)
+		}
+		if elem.DefaultValue != nil {
+			rule["default_value"] = policyValueString(elem.DefaultValue)
+		}
+		values := []interface{}{}
+		for _, v := range elem.Values {
+			values = append(values, policyValueString(v))
+		}
+		rule["values"] = values
+		if elem.MinValue != nil {
+			rule["min_value"] = *elem.MinValue
+		}
+		if elem.MaxValue != nil {
+			rule["max_value"] = *elem.MaxValue
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// suppressEquivalentJSON ignores formatting and key ordering differences
+func suppressEquivalentJSON(k, old, new string, d *schema.ResourceData) bool {
+	var oldJSON, newJSON interface{}
+	if err := json.Unmarshal([]byte(old), &oldJSON); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(new), &newJSON); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(oldJSON, newJSON)
+}
+
 func parsePolicyFromData(d *schema.ResourceData) (*ClusterPolicy, error) {
 	clusterPolicy := new(ClusterPolicy)
 	clusterPolicy.PolicyID = d.Id()
 	if name, ok := d.GetOk("name"); ok {
 		clusterPolicy.Name = name.(string)
 	}
-	if data, ok := d.GetOk("definition"); ok {
+	if maxClusters, ok := d.GetOk("max_clusters_per_user"); ok {
+		clusterPolicy.MaxClustersPerUser = int64(maxClusters.(int))
+	}
+	if familyID, ok := d.GetOk("policy_family_id"); ok {
+		// definition comes from the policy family
+		clusterPolicy.PolicyFamilyID = familyID.(string)
+		clusterPolicy.PolicyFamilyDefinitionOverrides = d.Get("policy_family_definition_overrides").(string)
+	} else if rules, ok := d.GetOk("rule"); ok {
+		definition, err := rulesToDefinition(rules.(*schema.Set).List(), configuredLimits(d))
+		if err != nil {
+			return nil, err
+		}
+		clusterPolicy.Definition = definition
+	} else if data, ok := d.GetOk("definition"); ok {
 		clusterPolicy.Definition = data.(string)
 	}
 	return clusterPolicy, nil
@@ -99,10 +274,103 @@ func ResourceClusterPolicy() *schema.Resource {
 			"definition": {
 				Type:     schema.TypeString,
 				Optional: true,
+				Computed: true,
 				Description: "Policy definition JSON document expressed in\n" +
 					"Databricks Policy Definition Language.",
-				ValidateFunc: validation.StringIsJSON,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentJSON,
+				ConflictsWith:    []string{"rule", "policy_family_id"},
+			},
+			"max_clusters_per_user": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Maximum number of clusters per user, that can be active using this policy.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"policy_family_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "ID of the policy family, that provides the policy definition.",
+				ConflictsWith: []string{"definition", "rule"},
 			},
+			"policy_family_definition_overrides": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Policy definition JSON document, that overrides\n" +
+					"elements of the policy family definition.",
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentJSON,
+				RequiredWith:     []string{"policy_family_id"},
+			},
+			"rule": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				ConflictsWith: []string{"definition", "policy_family_id"},
+				Description:   "Policy element as an alternative to JSON definition",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"path": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								PolicyTypeFixed, PolicyTypeForbidden, PolicyTypeAllowlist,
+								PolicyTypeBlocklist, PolicyTypeRegex, PolicyTypeRange,
+								PolicyTypeUnlimited,
+							}, false),
+						},
+						"value": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"values": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"min_value": {
+							Type:     schema.TypeFloat,
+							Optional: true,
+						},
+						"max_value": {
+							Type:     schema.TypeFloat,
+							Optional: true,
+						},
+						"pattern": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsValidRegExp,
+						},
+						"default_value": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"hidden": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"is_optional": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, c interface{}) error {
+			if d.HasChange("rule") && len(d.Get("rule").(*schema.Set).List()) > 0 {
+				// definition is derived from rules
+				return d.SetNewComputed("definition")
+			}
+			if d.Get("policy_family_id").(string) != "" &&
+				(d.HasChange("policy_family_id") || d.HasChange("policy_family_definition_overrides")) {
+				// definition is derived from policy family
+				return d.SetNewComputed("definition")
+			}
+			return nil
 		},
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			clusterPolicy, err := parsePolicyFromData(d)
@@ -129,7 +397,25 @@ func ResourceClusterPolicy() *schema.Resource {
 			if err = d.Set("policy_id", clusterPolicy.PolicyID); err != nil {
 				return err
 			}
-			return nil
+			if err = d.Set("max_clusters_per_user", clusterPolicy.MaxClustersPerUser); err != nil {
+				return err
+			}
+			if err = d.Set("policy_family_id", clusterPolicy.PolicyFamilyID); err != nil {
+				return err
+			}
+			if err = d.Set("policy_family_definition_overrides",
+				clusterPolicy.PolicyFamilyDefinitionOverrides); err != nil {
+				return err
+			}
+			if rules, ok := d.GetOk("rule"); !ok || rules.(*schema.Set).Len() == 0 {
+				// rules are tracked only when they are used instead of definition
+				return nil
+			}
+			rules, err := definitionToRules(clusterPolicy.Definition)
+			if err != nil {
+				return err
+			}
+			return d.Set("rule", rules)
 		},
 		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			clusterPolicy, err := parsePolicyFromData(d)
@@ -6,6 +6,7 @@ import (
 	"github.com/databrickslabs/terraform-provider-databricks/common"
 
 	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -236,3 +237,228 @@ func TestResourceClusterPolicyDelete_Error(t *testing.T) {
 	qa.AssertErrorStartsWith(t, err, "Internal error happened")
 	assert.Equal(t, "abc", d.Id())
 }
+
+func TestResourceClusterPolicyCreate_Rules(t *testing.T) {
+	definition := `{"autotermination_minutes":{"type":"fixed","value":"20","hidden":true},` +
+		`"custom_tags.Team":{"type":"fixed","value":"marketing"},` +
+		`"dbus_per_hour":{"type":"range","maxValue":10},` +
+		`"node_type_id":{"type":"allowlist","values":["i3.xlarge","i3.2xlarge"],"defaultValue":"i3.xlarge"},` +
+		`"spark_conf.spark.databricks.io.cache.enabled":{"type":"fixed","value":"true"}}`
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/policies/clusters/create",
+				ExpectedRequest: ClusterPolicy{
+					Name:       "Dummy",
+					Definition: definition,
+				},
+				Response: ClusterPolicy{
+					PolicyID: "abc",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/policies/clusters/get?policy_id=abc",
+				Response: ClusterPolicy{
+					PolicyID:   "abc",
+					Name:       "Dummy",
+					Definition: definition,
+				},
+			},
+		},
+		Resource: ResourceClusterPolicy(),
+		HCL: `
+		name = "Dummy"
+		rule {
+			path = "dbus_per_hour"
+			type = "range"
+			max_value = 10
+		}
+		rule {
+			path = "autotermination_minutes"
+			type = "fixed"
+			value = "20"
+			hidden = true
+		}
+		rule {
+			path = "custom_tags.Team"
+			type = "fixed"
+			value = "marketing"
+		}
+		rule {
+			path = "spark_conf.spark.databricks.io.cache.enabled"
+			type = "fixed"
+			value = "true"
+		}
+		rule {
+			path = "node_type_id"
+			type = "allowlist"
+			values = ["i3.xlarge", "i3.2xlarge"]
+			default_value = "i3.xlarge"
+		}`,
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, definition, d.Get("definition"))
+	assert.Equal(t, 5, d.Get("rule.#"))
+}
+
+func TestResourceClusterPolicyRead_Rules(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/policies/clusters/get?policy_id=abc",
+				Response: ClusterPolicy{
+					PolicyID:   "abc",
+					Name:       "Dummy",
+					Definition: `{"num_workers": {"type": "range", "minValue": 1, "maxValue": 4}}`,
+				},
+			},
+		},
+		Resource: ResourceClusterPolicy(),
+		Read:     true,
+		New:      true,
+		ID:       "abc",
+		HCL: `
+		name = "Dummy"
+		rule {
+			path = "num_workers"
+			type = "range"
+			max_value = 2
+		}`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	rules := d.Get("rule").(*schema.Set).List()
+	assert.Len(t, rules, 1)
+	rule := rules[0].(map[string]interface{})
+	assert.Equal(t, "num_workers", rule["path"])
+	assert.Equal(t, float64(1), rule["min_value"])
+	assert.Equal(t, float64(4), rule["max_value"])
+}
+
+func TestResourceClusterPolicyRules_ConflictWithDefinition(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceClusterPolicy(),
+		HCL: `
+		name = "Dummy"
+		definition = "{}"
+		rule {
+			path = "num_workers"
+			type = "forbidden"
+		}`,
+		Create: true,
+	}.ExpectError(t, "invalid config supplied. "+
+		"[definition] Conflicting configuration arguments. "+
+		"[rule] Conflicting configuration arguments")
+}
+
+func TestResourceClusterPolicyDefinitionDiffSuppress(t *testing.T) {
+	definition := ResourceClusterPolicy().Schema["definition"]
+	assert.True(t, definition.DiffSuppressFunc("definition",
+		`{"a": {"type": "fixed", "value": 1}, "b": {"type": "forbidden"}}`,
+		`{"b":{"type":"forbidden"},"a":{"value":1,"type":"fixed"}}`, nil))
+	assert.False(t, definition.DiffSuppressFunc("definition",
+		`{"a": {"type": "fixed", "value": 1}}`,
+		`{"a": {"type": "fixed", "value": "1"}}`, nil))
+}
+
+func TestResourceClusterPolicyCreate_ZeroLimits(t *testing.T) {
+	definition := `{"autotermination_minutes":{"type":"range","minValue":10,"maxValue":0},` +
+		`"spark_version":{"type":"fixed","value":"1.0"}}`
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/policies/clusters/create",
+				ExpectedRequest: ClusterPolicy{
+					Name:       "Dummy",
+					Definition: definition,
+				},
+				Response: ClusterPolicy{
+					PolicyID: "abc",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/policies/clusters/get?policy_id=abc",
+				Response: ClusterPolicy{
+					PolicyID:   "abc",
+					Name:       "Dummy",
+					Definition: definition,
+				},
+			},
+		},
+		Resource: ResourceClusterPolicy(),
+		HCL: `
+		name = "Dummy"
+		rule {
+			path = "autotermination_minutes"
+			type = "range"
+			min_value = 10
+			max_value = 0
+		}
+		rule {
+			path = "spark_version"
+			type = "fixed"
+			value = "1.0"
+		}`,
+		Create: true,
+	}.ApplyNoError(t)
+}
+
+func TestTypedPolicyValue(t *testing.T) {
+	assert.Equal(t, "true", typedPolicyValue("enable_elastic_disk", "fixed", "true"))
+	assert.Equal(t, "20", typedPolicyValue("autotermination_minutes", "fixed", "20"))
+	assert.Equal(t, "1.0", typedPolicyValue("spark_version", "allowlist", "1.0"))
+	assert.Equal(t, float64(20), typedPolicyValue("autotermination_minutes", "range", "20"))
+	assert.Equal(t, float64(1), typedPolicyValue("num_workers", "range", "1.0"))
+	assert.Equal(t, "abc", typedPolicyValue("num_workers", "range", "abc"))
+	assert.Equal(t, "1", typedPolicyValue("custom_tags.Priority", "range", "1"))
+}
+
+func TestResourceClusterPolicyCreate_PolicyFamily(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/policies/clusters/create",
+				// definition comes from the policy family and must not be sent
+				ExpectedRequest: map[string]interface{}{
+					"name":                               "Personal Compute",
+					"max_clusters_per_user":              1,
+					"policy_family_id":                   "personal-vm",
+					"policy_family_definition_overrides": `{"autotermination_minutes": {"type": "fixed", "value": 30}}`,
+				},
+				Response: ClusterPolicy{
+					PolicyID: "abc",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/policies/clusters/get?policy_id=abc",
+				Response: ClusterPolicy{
+					PolicyID:                        "abc",
+					Name:                            "Personal Compute",
+					Definition:                      `{"autotermination_minutes":{"type":"fixed","value":30},"num_workers":{"type":"fixed","value":0}}`,
+					MaxClustersPerUser:              1,
+					PolicyFamilyID:                  "personal-vm",
+					PolicyFamilyDefinitionOverrides: `{"autotermination_minutes":{"value":30,"type":"fixed"}}`,
+				},
+			},
+		},
+		Resource: ResourceClusterPolicy(),
+		HCL: `
+		name = "Personal Compute"
+		max_clusters_per_user = 1
+		policy_family_id = "personal-vm"
+		policy_family_definition_overrides = "{\"autotermination_minutes\": {\"type\": \"fixed\", \"value\": 30}}"`,
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, 1, d.Get("max_clusters_per_user"))
+	assert.Equal(t, "personal-vm", d.Get("policy_family_id"))
+}
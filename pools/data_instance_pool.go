@@ -0,0 +1,68 @@
+package pools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// GetByName returns instance pool with the exact name, including its usage statistics
+func (a InstancePoolsAPI) GetByName(name string) (*InstancePoolAndStats, error) {
+	ipl, err := a.List()
+	if err != nil {
+		return nil, err
+	}
+	var found []InstancePoolAndStats
+	for _, pool := range ipl.InstancePools {
+		if pool.InstancePoolName == name {
+			found = append(found, pool)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("instance pool '%s' wasn't found", name)
+	case 1:
+		return &found[0], nil
+	}
+	ids := []string{}
+	for _, pool := range found {
+		ids = append(ids, pool.InstancePoolID)
+	}
+	return nil, fmt.Errorf("there are %d instance pools with name '%s': %v", len(found), name, ids)
+}
+
+// DataSourceInstancePool returns information about instance pool specified by its exact name
+func DataSourceInstancePool() *schema.Resource {
+	type poolData struct {
+		Name         string                `json:"name"`
+		InstancePool *InstancePoolAndStats `json:"pool_info,omitempty" tf:"computed"`
+	}
+	s := common.StructToSchema(poolData{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		common.MarkComputed(s["pool_info"].Elem.(*schema.Resource).Schema)
+		return s
+	})
+	return &schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			var data poolData
+			err := common.DataToStructPointer(d, s, &data)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			data.InstancePool, err = NewInstancePoolsAPI(ctx, m).GetByName(data.Name)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			err = common.StructToData(data, s, d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId(data.InstancePool.InstancePoolID)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,101 @@
+package pools
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+)
+
+var testInstancePoolList = InstancePoolList{
+	InstancePools: []InstancePoolAndStats{
+		{
+			InstancePoolID:                     "abc",
+			InstancePoolName:                   "Shared Pool",
+			NodeTypeID:                         "i3.xlarge",
+			MinIdleInstances:                   1,
+			MaxCapacity:                        10,
+			IdleInstanceAutoTerminationMinutes: 15,
+			State:                              "ACTIVE",
+			Stats: &InstancePoolStats{
+				UsedCount: 3,
+				IdleCount: 1,
+			},
+			DefaultTags: map[string]string{
+				"DatabricksInstancePoolId": "abc",
+			},
+		},
+		{
+			InstancePoolID:   "def",
+			InstancePoolName: "Duplicate",
+			NodeTypeID:       "i3.xlarge",
+		},
+		{
+			InstancePoolID:   "ghi",
+			InstancePoolName: "Duplicate",
+			NodeTypeID:       "i3.2xlarge",
+		},
+	},
+}
+
+func TestDataSourceInstancePool(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/instance-pools/list",
+				Response: testInstancePoolList,
+			},
+		},
+		Resource:    DataSourceInstancePool(),
+		NonWritable: true,
+		Read:        true,
+		New:         true,
+		ID:          "_",
+		HCL:         `name = "Shared Pool"`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, "i3.xlarge", d.Get("pool_info.0.node_type_id"))
+	assert.Equal(t, "ACTIVE", d.Get("pool_info.0.state"))
+	assert.Equal(t, 10, d.Get("pool_info.0.max_capacity"))
+	assert.Equal(t, 3, d.Get("pool_info.0.stats.0.used_count"))
+	assert.Equal(t, 1, d.Get("pool_info.0.stats.0.idle_count"))
+	assert.Equal(t, "abc", d.Get("pool_info.0.default_tags.DatabricksInstancePoolId"))
+}
+
+func TestDataSourceInstancePool_NotFound(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/instance-pools/list",
+				Response: testInstancePoolList,
+			},
+		},
+		Resource:    DataSourceInstancePool(),
+		NonWritable: true,
+		Read:        true,
+		New:         true,
+		ID:          "_",
+		HCL:         `name = "Unknown"`,
+	}.ExpectError(t, "instance pool 'Unknown' wasn't found")
+}
+
+func TestDataSourceInstancePool_Duplicates(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/instance-pools/list",
+				Response: testInstancePoolList,
+			},
+		},
+		Resource:    DataSourceInstancePool(),
+		NonWritable: true,
+		Read:        true,
+		New:         true,
+		ID:          "_",
+		HCL:         `name = "Duplicate"`,
+	}.ExpectError(t, "there are 2 instance pools with name 'Duplicate': [def ghi]")
+}
@@ -0,0 +1,55 @@
+package pools
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DataSourceInstancePools returns IDs of instance pools, optionally filtered by name
+func DataSourceInstancePools() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			ipl, err := NewInstancePoolsAPI(ctx, m).List()
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			ids := schema.NewSet(schema.HashString, []interface{}{})
+			names := map[string]interface{}{}
+			nameContains := strings.ToLower(d.Get("instance_pool_name_contains").(string))
+			for _, v := range ipl.InstancePools {
+				if nameContains != "" && !strings.Contains(strings.ToLower(v.InstancePoolName), nameContains) {
+					continue
+				}
+				ids.Add(v.InstancePoolID)
+				names[v.InstancePoolName] = v.InstancePoolID
+			}
+			d.Set("ids", ids)
+			d.Set("pools", names)
+			d.SetId("_")
+			return nil
+		},
+		Schema: map[string]*schema.Schema{
+			"ids": {
+				Computed: true,
+				Type:     schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"pools": {
+				Computed: true,
+				Type:     schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"instance_pool_name_contains": {
+				Optional: true,
+				Type:     schema.TypeString,
+			},
+		},
+	}
+}
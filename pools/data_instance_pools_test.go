@@ -0,0 +1,52 @@
+package pools
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataSourceInstancePools(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/instance-pools/list",
+				Response: testInstancePoolList,
+			},
+		},
+		Resource:    DataSourceInstancePools(),
+		NonWritable: true,
+		Read:        true,
+		New:         true,
+		ID:          "_",
+		HCL:         `instance_pool_name_contains = "shared"`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, []interface{}{"abc"}, d.Get("ids").(*schema.Set).List())
+	assert.Equal(t, map[string]interface{}{"Shared Pool": "abc"}, d.Get("pools"))
+}
+
+func TestDataSourceInstancePools_Error(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/instance-pools/list",
+				Response: common.APIErrorBody{
+					ErrorCode: "INVALID_REQUEST",
+					Message:   "Internal error happened",
+				},
+				Status: 400,
+			},
+		},
+		Resource:    DataSourceInstancePools(),
+		NonWritable: true,
+		Read:        true,
+		New:         true,
+		ID:          "_",
+	}.ExpectError(t, "Internal error happened")
+}
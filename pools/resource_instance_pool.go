@@ -24,6 +24,13 @@ type InstancePoolAzureAttributes struct {
 	SpotBidMaxPrice float64               `json:"spot_bid_max_price,omitempty" tf:"force_new"`
 }
 
+// InstancePoolGcpAttributes contains attributes for GCP Databricks deployments for instance pools
+// https://docs.gcp.databricks.com/dev-tools/api/latest/instance-pools.html#instancepoolgcpattributes
+type InstancePoolGcpAttributes struct {
+	Availability  clusters.Availability `json:"gcp_availability,omitempty" tf:"force_new"`
+	LocalSsdCount int32                 `json:"local_ssd_count,omitempty" tf:"force_new"`
+}
+
 // InstancePoolDiskType contains disk type information for each of the different cloud service providers
 type InstancePoolDiskType struct {
 	AzureDiskVolumeType string `json:"azure_disk_volume_type,omitempty" tf:"force_new"`
@@ -37,7 +44,8 @@ type InstancePoolDiskSpec struct {
 	DiskSize  int32                 `json:"disk_size,omitempty"`
 }
 
-// InstancePool describes the instance pool object on Databricks
+// InstancePool describes the instance pool object on Databricks. Only name, capacity,
+// idle settings and custom tags could be edited, all other changes require a new pool.
 type InstancePool struct {
 	InstancePoolID                     string                       `json:"instance_pool_id,omitempty" tf:"computed"`
 	InstancePoolName                   string                       `json:"instance_pool_name"`
@@ -46,14 +54,28 @@ type InstancePool struct {
 	IdleInstanceAutoTerminationMinutes int32                        `json:"idle_instance_autotermination_minutes"`
 	AwsAttributes                      *InstancePoolAwsAttributes   `json:"aws_attributes,omitempty" tf:"force_new,suppress_diff"`
 	AzureAttributes                    *InstancePoolAzureAttributes `json:"azure_attributes,omitempty" tf:"force_new,suppress_diff"`
+	GcpAttributes                      *InstancePoolGcpAttributes   `json:"gcp_attributes,omitempty" tf:"force_new,suppress_diff"`
 	NodeTypeID                         string                       `json:"node_type_id" tf:"force_new"`
-	CustomTags                         map[string]string            `json:"custom_tags,omitempty" tf:"force_new"`
+	CustomTags                         map[string]string            `json:"custom_tags,omitempty"`
 	EnableElasticDisk                  bool                         `json:"enable_elastic_disk,omitempty" tf:"force_new"`
 	DiskSpec                           *InstancePoolDiskSpec        `json:"disk_spec,omitempty" tf:"force_new"`
 	PreloadedSparkVersions             []string                     `json:"preloaded_spark_versions,omitempty" tf:"force_new"`
 	PreloadedDockerImages              []clusters.DockerImage       `json:"preloaded_docker_images,omitempty" tf:"force_new,slice_set,alias:preloaded_docker_image"`
 }
 
+// instancePoolEdit contains only the attributes, that are accepted by edit API.
+// Preloaded Spark versions and Docker images are not among them, as they are
+// installed only when instances are launched.
+type instancePoolEdit struct {
+	InstancePoolID                     string            `json:"instance_pool_id"`
+	InstancePoolName                   string            `json:"instance_pool_name"`
+	MinIdleInstances                   int32             `json:"min_idle_instances"`
+	MaxCapacity                        int32             `json:"max_capacity,omitempty"`
+	IdleInstanceAutoTerminationMinutes int32             `json:"idle_instance_autotermination_minutes"`
+	NodeTypeID                         string            `json:"node_type_id"`
+	CustomTags                         map[string]string `json:"custom_tags,omitempty"`
+}
+
 // InstancePoolStats contains the stats on a given pool
 type InstancePoolStats struct {
 	UsedCount        int32 `json:"used_count,omitempty"`
@@ -70,6 +92,7 @@ type InstancePoolAndStats struct {
 	MaxCapacity                        int32                        `json:"max_capacity,omitempty"`
 	AwsAttributes                      *InstancePoolAwsAttributes   `json:"aws_attributes,omitempty"`
 	AzureAttributes                    *InstancePoolAzureAttributes `json:"azure_attributes,omitempty"`
+	GcpAttributes                      *InstancePoolGcpAttributes   `json:"gcp_attributes,omitempty"`
 	NodeTypeID                         string                       `json:"node_type_id"`
 	DefaultTags                        map[string]string            `json:"default_tags,omitempty" tf:"computed"`
 	CustomTags                         map[string]string            `json:"custom_tags,omitempty"`
@@ -105,9 +128,18 @@ func (a InstancePoolsAPI) Create(instancePool InstancePool) (InstancePoolAndStat
 	return instancePoolInfo, err
 }
 
-// Update edits the configuration of a instance pool to match the provided attributes and size
+// Update edits the configuration of a instance pool to match the provided attributes and size.
+// Node type cannot be changed, but it's required by the edit API.
 func (a InstancePoolsAPI) Update(ip InstancePool) error {
-	return a.client.Post(a.context, "/instance-pools/edit", ip, nil)
+	return a.client.Post(a.context, "/instance-pools/edit", instancePoolEdit{
+		InstancePoolID:                     ip.InstancePoolID,
+		InstancePoolName:                   ip.InstancePoolName,
+		MinIdleInstances:                   ip.MinIdleInstances,
+		MaxCapacity:                        ip.MaxCapacity,
+		IdleInstanceAutoTerminationMinutes: ip.IdleInstanceAutoTerminationMinutes,
+		NodeTypeID:                         ip.NodeTypeID,
+		CustomTags:                         ip.CustomTags,
+	}, nil)
 }
 
 // Read retrieves the information for a instance pool given its identifier
@@ -135,8 +167,9 @@ func (a InstancePoolsAPI) Delete(instancePoolID string) error {
 func ResourceInstancePool() *schema.Resource {
 	s := common.StructToSchema(InstancePool{}, func(s map[string]*schema.Schema) map[string]*schema.Schema {
 		s["enable_elastic_disk"].Default = true
-		s["aws_attributes"].ConflictsWith = []string{"azure_attributes"}
-		s["azure_attributes"].ConflictsWith = []string{"aws_attributes"}
+		s["aws_attributes"].ConflictsWith = []string{"azure_attributes", "gcp_attributes"}
+		s["azure_attributes"].ConflictsWith = []string{"aws_attributes", "gcp_attributes"}
+		s["gcp_attributes"].ConflictsWith = []string{"aws_attributes", "azure_attributes"}
 		if v, err := common.SchemaPath(s, "aws_attributes", "availability"); err == nil {
 			v.Default = clusters.AwsAvailabilitySpot
 			v.ValidateFunc = validation.StringInSlice([]string{
@@ -154,6 +187,13 @@ func ResourceInstancePool() *schema.Resource {
 				clusters.AzureAvailabilityOnDemand,
 			}, false)
 		}
+		if v, err := common.SchemaPath(s, "gcp_attributes", "gcp_availability"); err == nil {
+			v.ValidateFunc = validation.StringInSlice([]string{
+				clusters.GcpAvailabilityPreemptible,
+				clusters.GcpAvailabilityOnDemand,
+				clusters.GcpAvailabilityPreemptibleWithFallback,
+			}, false)
+		}
 		if v, err := common.SchemaPath(s, "disk_spec", "disk_type", "azure_disk_volume_type"); err == nil {
 			// nolint
 			v.ValidateFunc = validation.StringInSlice([]string{
@@ -116,6 +116,58 @@ func TestResourceInstancePoolCreate(t *testing.T) {
 	assert.Equal(t, "abc", d.Id())
 }
 
+func TestResourceInstancePoolCreate_Gcp(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/instance-pools/create",
+				ExpectedRequest: InstancePool{
+					InstancePoolName:                   "Shared Pool",
+					NodeTypeID:                         "n1-standard-4",
+					IdleInstanceAutoTerminationMinutes: 15,
+					EnableElasticDisk:                  true,
+					GcpAttributes: &InstancePoolGcpAttributes{
+						Availability:  clusters.GcpAvailabilityPreemptibleWithFallback,
+						LocalSsdCount: 1,
+					},
+				},
+				Response: InstancePoolAndStats{
+					InstancePoolID: "abc",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/instance-pools/get?instance_pool_id=abc",
+				Response: InstancePoolAndStats{
+					InstancePoolID:                     "abc",
+					InstancePoolName:                   "Shared Pool",
+					NodeTypeID:                         "n1-standard-4",
+					IdleInstanceAutoTerminationMinutes: 15,
+					EnableElasticDisk:                  true,
+					GcpAttributes: &InstancePoolGcpAttributes{
+						Availability:  clusters.GcpAvailabilityPreemptibleWithFallback,
+						LocalSsdCount: 1,
+					},
+				},
+			},
+		},
+		Resource: ResourceInstancePool(),
+		HCL: `
+		instance_pool_name = "Shared Pool"
+		node_type_id = "n1-standard-4"
+		idle_instance_autotermination_minutes = 15
+		gcp_attributes {
+			gcp_availability = "PREEMPTIBLE_WITH_FALLBACK_GCP"
+			local_ssd_count = 1
+		}`,
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, 1, d.Get("gcp_attributes.0.local_ssd_count"))
+}
+
 func TestResourceInstancePoolCreate_Error(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -221,8 +273,7 @@ func TestResourceInstancePoolUpdate(t *testing.T) {
 			{
 				Method:   "POST",
 				Resource: "/api/2.0/instance-pools/edit",
-				ExpectedRequest: InstancePoolAndStats{
-					EnableElasticDisk:                  true,
+				ExpectedRequest: instancePoolEdit{
 					InstancePoolID:                     "abc",
 					MaxCapacity:                        500,
 					NodeTypeID:                         "i3.xlarge",
@@ -263,6 +314,138 @@ func TestResourceInstancePoolUpdate(t *testing.T) {
 	assert.NoError(t, err, err)
 	assert.Equal(t, "abc", d.Id(), "Id should be the same as in reading")
 }
+
+func TestResourceInstancePoolUpdate_ZeroMinIdleInstances(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/instance-pools/edit",
+				ExpectedRequest: map[string]interface{}{
+					"instance_pool_id":                      "abc",
+					"instance_pool_name":                    "Restricted Pool",
+					"min_idle_instances":                    0,
+					"max_capacity":                          500,
+					"idle_instance_autotermination_minutes": 20,
+					"node_type_id":                          "i3.xlarge",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/instance-pools/get?instance_pool_id=abc",
+				Response: InstancePoolAndStats{
+					InstancePoolID:                     "abc",
+					MaxCapacity:                        500,
+					NodeTypeID:                         "i3.xlarge",
+					IdleInstanceAutoTerminationMinutes: 20,
+					InstancePoolName:                   "Restricted Pool",
+					EnableElasticDisk:                  true,
+				},
+			},
+		},
+		Resource: ResourceInstancePool(),
+		HCL: `
+		idle_instance_autotermination_minutes = 20
+		instance_pool_name = "Restricted Pool"
+		max_capacity = 500
+		node_type_id = "i3.xlarge"`,
+		InstanceState: map[string]string{
+			"idle_instance_autotermination_minutes": "20",
+			"instance_pool_name":                    "Restricted Pool",
+			"max_capacity":                          "500",
+			"min_idle_instances":                    "5",
+			"node_type_id":                          "i3.xlarge",
+			"enable_elastic_disk":                   "true",
+		},
+		Update: true,
+		ID:     "abc",
+	}.ApplyNoError(t)
+}
+
+func TestResourceInstancePoolUpdate_CustomTagsInPlace(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/instance-pools/edit",
+				ExpectedRequest: instancePoolEdit{
+					InstancePoolID:                     "abc",
+					InstancePoolName:                   "Shared Pool",
+					NodeTypeID:                         "i3.xlarge",
+					IdleInstanceAutoTerminationMinutes: 15,
+					MaxCapacity:                        100,
+					CustomTags: map[string]string{
+						"Team": "data",
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/instance-pools/get?instance_pool_id=abc",
+				Response: InstancePoolAndStats{
+					InstancePoolID:                     "abc",
+					InstancePoolName:                   "Shared Pool",
+					NodeTypeID:                         "i3.xlarge",
+					IdleInstanceAutoTerminationMinutes: 15,
+					MaxCapacity:                        100,
+					EnableElasticDisk:                  true,
+					PreloadedSparkVersions:             []string{"7.3.x-scala2.12"},
+					CustomTags: map[string]string{
+						"Team": "data",
+					},
+				},
+			},
+		},
+		Resource: ResourceInstancePool(),
+		InstanceState: map[string]string{
+			"instance_pool_name":                    "Shared Pool",
+			"node_type_id":                          "i3.xlarge",
+			"idle_instance_autotermination_minutes": "15",
+			"max_capacity":                          "50",
+			"enable_elastic_disk":                   "true",
+			"preloaded_spark_versions.#":            "1",
+			"preloaded_spark_versions.0":            "7.3.x-scala2.12",
+			"custom_tags.%":                         "1",
+			"custom_tags.Team":                      "platform",
+		},
+		HCL: `
+		instance_pool_name = "Shared Pool"
+		node_type_id = "i3.xlarge"
+		idle_instance_autotermination_minutes = 15
+		max_capacity = 100
+		preloaded_spark_versions = ["7.3.x-scala2.12"]
+		custom_tags = {
+			"Team" = "data"
+		}`,
+		Update: true,
+		ID:     "abc",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "data", d.Get("custom_tags.Team"))
+	assert.Equal(t, 100, d.Get("max_capacity"))
+}
+
+func TestResourceInstancePoolUpdate_PreloadedSparkVersionsRequireNew(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceInstancePool(),
+		InstanceState: map[string]string{
+			"instance_pool_name":                    "Shared Pool",
+			"node_type_id":                          "i3.xlarge",
+			"idle_instance_autotermination_minutes": "15",
+			"enable_elastic_disk":                   "true",
+			"preloaded_spark_versions.#":            "1",
+			"preloaded_spark_versions.0":            "7.3.x-scala2.12",
+		},
+		HCL: `
+		instance_pool_name = "Shared Pool"
+		node_type_id = "i3.xlarge"
+		idle_instance_autotermination_minutes = 15
+		preloaded_spark_versions = ["9.1.x-scala2.12"]`,
+		Update: true,
+		ID:     "abc",
+	}.ExpectError(t, "changes require new: preloaded_spark_versions.0")
+}
+
 func TestResourceInstancePoolUpdate_Error(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -38,16 +38,25 @@ func DatabricksProvider() *schema.Provider {
 			"databricks_aws_crossaccount_policy": aws.DataAwsCrossAccountPolicy(),
 			"databricks_aws_assume_role_policy":  aws.DataAwsAssumeRolePolicy(),
 			"databricks_aws_bucket_policy":       aws.DataAwsBucketPolicy(),
+			"databricks_cluster":                 clusters.DataSourceCluster(),
+			"databricks_cluster_events":          clusters.DataSourceClusterEvents(),
+			"databricks_cluster_policies":        policies.DataSourceClusterPolicies(),
+			"databricks_cluster_policy":          policies.DataSourceClusterPolicy(),
 			"databricks_clusters":                clusters.DataSourceClusters(),
+			"databricks_command":                 commands.DataSourceCommand(),
 			"databricks_current_user":            scim.DataSourceCurrentUser(),
 			"databricks_dbfs_file":               storage.DataSourceDBFSFile(),
 			"databricks_dbfs_file_paths":         storage.DataSourceDBFSFilePaths(),
 			"databricks_group":                   scim.DataSourceGroup(),
+			"databricks_instance_pool":           pools.DataSourceInstancePool(),
+			"databricks_instance_pools":          pools.DataSourceInstancePools(),
+			"databricks_mounts":                  storage.DataSourceMounts(),
 			"databricks_node_type":               clusters.DataSourceNodeType(),
 			"databricks_notebook":                workspace.DataSourceNotebook(),
 			"databricks_notebook_paths":          workspace.DataSourceNotebookPaths(),
 			"databricks_spark_version":           clusters.DataSourceSparkVersion(),
 			"databricks_user":                    scim.DataSourceUser(),
+			"databricks_workspace_file":          workspace.DataSourceWorkspaceFile(),
 			"databricks_zones":                   clusters.DataSourceClusterZones(),
 		},
 		ResourcesMap: map[string]*schema.Resource{ // must be in alphabetical order
@@ -58,6 +67,8 @@ func DatabricksProvider() *schema.Provider {
 			"databricks_catalog":                     catalog.ResourceCatalog(),
 			"databricks_cluster":                     clusters.ResourceCluster(),
 			"databricks_cluster_policy":              policies.ResourceClusterPolicy(),
+			"databricks_command":                     commands.ResourceCommand(),
+			"databricks_dbfs_directory":              storage.ResourceDBFSDirectory(),
 			"databricks_dbfs_file":                   storage.ResourceDBFSFile(),
 			"databricks_directory":                   workspace.ResourceDirectory(),
 			"databricks_global_init_script":          workspace.ResourceGlobalInitScript(),
@@ -76,6 +87,7 @@ func DatabricksProvider() *schema.Provider {
 			"databricks_mlflow_experiment":           mlflow.ResourceMLFlowExperiment(),
 			"databricks_mlflow_model":                mlflow.ResourceMLFlowModel(),
 			"databricks_mount":                       storage.ResourceDatabricksMount(),
+			"databricks_mounts":                      storage.ResourceDatabricksMounts(),
 			"databricks_mws_customer_managed_keys":   mws.ResourceCustomerManagedKey(),
 			"databricks_mws_credentials":             mws.ResourceCredentials(),
 			"databricks_mws_log_delivery":            mws.ResourceLogDelivery(),
@@ -85,6 +97,7 @@ func DatabricksProvider() *schema.Provider {
 			"databricks_mws_vpc_endpoint":            mws.ResourceVPCEndpoint(),
 			"databricks_mws_workspaces":              mws.ResourceWorkspace(),
 			"databricks_notebook":                    workspace.ResourceNotebook(),
+			"databricks_notification_destination":    jobs.ResourceNotificationDestination(),
 			"databricks_obo_token":                   tokens.ResourceOboToken(),
 			"databricks_permissions":                 permissions.ResourcePermissions(),
 			"databricks_pipeline":                    pipelines.ResourcePipeline(),
@@ -105,6 +118,7 @@ func DatabricksProvider() *schema.Provider {
 			"databricks_user":                        scim.ResourceUser(),
 			"databricks_user_instance_profile":       aws.ResourceUserInstanceProfile(),
 			"databricks_workspace_conf":              workspace.ResourceWorkspaceConf(),
+			"databricks_workspace_file":              workspace.ResourceWorkspaceFile(),
 		},
 		Schema: providerSchema(),
 	}
@@ -21,6 +21,7 @@ import (
 	"github.com/databrickslabs/terraform-provider-databricks/internal"
 
 	"github.com/hashicorp/go-cty/cty"
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
 	"github.com/hashicorp/hcl"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -192,6 +193,7 @@ func (f ResourceFixture) Apply(t *testing.T) (*schema.ResourceData, error) {
 	schemaMap := schema.InternalMap(f.Resource.Schema)
 	is := &terraform.InstanceState{
 		Attributes: f.InstanceState,
+		RawConfig:  rawConfig(f.Resource, f.State),
 	}
 	ctx := context.Background()
 	diff, err := f.Resource.Diff(ctx, is, resourceConfig, client)
@@ -216,7 +218,7 @@ func (f ResourceFixture) Apply(t *testing.T) (*schema.ResourceData, error) {
 	if execute != nil {
 		// this is a bit strange, but we'll fix it later
 		diags := execute(ctx, resourceData, client)
-		if diags != nil {
+		if diags.HasError() {
 			return resourceData, fmt.Errorf(diagsToString(diags))
 		}
 	}
@@ -235,6 +237,25 @@ func (f ResourceFixture) Apply(t *testing.T) (*schema.ResourceData, error) {
 	return resourceData, err
 }
 
+// rawConfig converts configuration into the value, that is returned from GetRawConfig,
+// or null value, if configuration cannot be converted
+func rawConfig(r *schema.Resource, config map[string]interface{}) cty.Value {
+	impliedType := r.CoreConfigSchema().ImpliedType()
+	if config == nil {
+		return cty.NullVal(impliedType)
+	}
+	raw, err := json.Marshal(config)
+	if err != nil {
+		return cty.NullVal(impliedType)
+	}
+	value, err := ctyjson.Unmarshal(raw, impliedType)
+	if err != nil {
+		log.Printf("[WARN] Raw config is not available: %s", err)
+		return cty.NullVal(impliedType)
+	}
+	return value
+}
+
 func (f ResourceFixture) requiresNew(diff *terraform.InstanceDiff) error {
 	requireNew := []string{}
 	for k, v := range diff.Attributes {
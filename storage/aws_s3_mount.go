@@ -83,6 +83,10 @@ func ResourceAWSS3Mount() *schema.Resource {
 		return mountCreate(tpl, r)(ctx, d, m)
 	}
 	r.ReadContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		if trustMountState(d) {
+			// no need to check the mounting cluster
+			return nil
+		}
 		if err := preprocessS3Mount(ctx, d, m); err != nil {
 			return diag.FromErr(err)
 		}
@@ -94,7 +98,7 @@ func ResourceAWSS3Mount() *schema.Resource {
 		}
 		return mountDelete(tpl, r)(ctx, d, m)
 	}
-	return r
+	return withRefreshViaCluster(r)
 }
 
 func preprocessS3Mount(ctx context.Context, d *schema.ResourceData, m interface{}) error {
@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"encoding/base64"
+	"os"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -18,13 +19,21 @@ func DataSourceDBFSFile() *schema.Resource {
 			if err != nil {
 				return diag.FromErr(err)
 			}
-			if limitFileSize && fileInfo.FileSize > 4e6 {
+			if limitFileSize && fileInfo.FileSize > 4e6 && d.Get("output_path").(string) == "" {
 				return diag.Errorf("Size of %s is too large: %d bytes",
 					fileInfo.Path, fileInfo.FileSize)
 			}
 			d.SetId(fileInfo.Path)
 			d.Set("path", fileInfo.Path)
 			d.Set("file_size", fileInfo.FileSize)
+			if outputPath := d.Get("output_path").(string); outputPath != "" {
+				// content is streamed to local file instead of being kept in state
+				err = downloadTo(dbfsAPI, fileInfo.Path, outputPath)
+				if err != nil {
+					return diag.FromErr(err)
+				}
+				return nil
+			}
 			content, err := dbfsAPI.Read(fileInfo.Path)
 			if err != nil {
 				return diag.FromErr(err)
@@ -52,6 +61,26 @@ func DataSourceDBFSFile() *schema.Resource {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
+			"output_path": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
 		},
 	}
 }
+
+// downloadTo streams file from DBFS to a local path
+func downloadTo(dbfsAPI DbfsAPI, path, outputPath string) (err error) {
+	f, err := os.Create(outputPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		cerr := f.Close()
+		if cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+	return dbfsAPI.ReadTo(path, f)
+}
@@ -1,6 +1,8 @@
 package storage
 
 import (
+	"io/ioutil"
+	"path/filepath"
 	"testing"
 
 	"github.com/databrickslabs/terraform-provider-databricks/qa"
@@ -42,3 +44,41 @@ func TestDataSourceFile(t *testing.T) {
 	assert.Equal(t, "/a/b/c", d.Id())
 	assert.Equal(t, "SGVsbG8gd29ybGQK", d.Get("content"))
 }
+
+func TestDataSourceFile_OutputPath(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "c.txt")
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/dbfs/get-status?path=%2Fa%2Fb%2Fc",
+				Response: FileInfo{
+					Path:     "/a/b/c",
+					FileSize: 5e6,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/dbfs/read?length=1000000&path=%2Fa%2Fb%2Fc",
+				Response: ReadResponse{
+					BytesRead: 12,
+					Data:      "SGVsbG8gd29ybGQK",
+				},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceDBFSFile(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"path":            "/a/b/c",
+			"limit_file_size": true,
+			"output_path":     outputPath,
+		},
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, "", d.Get("content"))
+	content, err := ioutil.ReadFile(outputPath)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello world\n", string(content))
+}
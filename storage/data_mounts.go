@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/databrickslabs/terraform-provider-databricks/clusters"
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DataSourceMounts lists mount points, that are visible on the given or mounting cluster
+func DataSourceMounts() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			client := m.(*common.DatabricksClient)
+			clustersAPI := clusters.NewClustersAPI(ctx, client)
+			clusterID := d.Get("cluster_id").(string)
+			var err error
+			if clusterID == "" {
+				clusterID, err = getOrCreateMountingCluster(clustersAPI)
+			} else {
+				// unlike mount resources, given cluster is never replaced with the mounting one
+				_, err = clustersAPI.StartAndGetInfo(clusterID)
+			}
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			mountInfos, err := listMountInfos(client.CommandExecutor(ctx), clusterID)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			mounts := map[string]string{}
+			encryptionTypes := map[string]string{}
+			for _, mi := range mountInfos {
+				mounts[mi.MountPoint] = mi.Source
+				if mi.EncryptionType != "" {
+					encryptionTypes[mi.MountPoint] = mi.EncryptionType
+				}
+			}
+			d.SetId(clusterID)
+			// nolint
+			d.Set("cluster_id", clusterID)
+			// nolint
+			d.Set("mounts", mounts)
+			// nolint
+			d.Set("encryption_types", encryptionTypes)
+			return nil
+		},
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"mounts": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"encryption_types": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
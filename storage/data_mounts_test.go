@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/clusters"
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/internal"
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataSourceMounts(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/get?cluster_id=abc",
+				Response: clusters.ClusterInfo{
+					ClusterID: "abc",
+					State:     clusters.ClusterStateRunning,
+				},
+			},
+		},
+		CommandMock: func(commandStr string) common.CommandResults {
+			assert.Contains(t, internal.TrimLeadingWhitespace(commandStr), "dbutils.fs.mounts()")
+			return common.CommandResults{
+				ResultType: "text",
+				Data: `[{"mount_point": "/databricks-datasets", "source": "databricks-datasets", "encryption_type": ""},
+					{"mount_point": "/mnt/a", "source": "s3a://a", "encryption_type": "sse-s3"}]`,
+			}
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceMounts(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"cluster_id": "abc",
+		},
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, map[string]interface{}{
+		"/databricks-datasets": "databricks-datasets",
+		"/mnt/a":               "s3a://a",
+	}, d.Get("mounts"))
+	assert.Equal(t, map[string]interface{}{
+		"/mnt/a": "sse-s3",
+	}, d.Get("encryption_types"))
+}
+
+func TestDataSourceMounts_Error(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/get?cluster_id=abc",
+				Response: clusters.ClusterInfo{
+					ClusterID: "abc",
+					State:     clusters.ClusterStateRunning,
+				},
+			},
+		},
+		CommandMock: func(commandStr string) common.CommandResults {
+			return common.CommandResults{
+				ResultType: "error",
+				Summary:    "Cluster is terminating",
+			}
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceMounts(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"cluster_id": "abc",
+		},
+	}.ExpectError(t, "Cluster is terminating")
+}
+
+func TestDataSourceMounts_MissingCluster(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/get?cluster_id=abc",
+				Status:   400,
+				Response: common.APIErrorBody{
+					ErrorCode: "INVALID_PARAMETER_VALUE",
+					Message:   "Cluster abc does not exist",
+				},
+			},
+		},
+		CommandMock: func(commandStr string) common.CommandResults {
+			t.Fatalf("Unexpected command:\n%s", commandStr)
+			return common.CommandResults{}
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceMounts(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"cluster_id": "abc",
+		},
+	}.ExpectError(t, "Cluster abc does not exist")
+}
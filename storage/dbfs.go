@@ -3,7 +3,11 @@ package storage
 import (
 	"bytes"
 	"context"
+	"crypto/md5"
 	"encoding/base64"
+	"fmt"
+	"io"
+	"log"
 
 	"github.com/databrickslabs/terraform-provider-databricks/common"
 )
@@ -56,31 +60,117 @@ type DbfsAPI struct {
 
 // Create creates a file on DBFS
 func (a DbfsAPI) Create(path string, byteArr []byte, overwrite bool) (err error) {
+	return a.CreateFromReader(path, bytes.NewReader(byteArr), overwrite)
+}
+
+// maximum size of a block, that could be added to DBFS handle
+const dbfsBlockSize = 1e6
+
+// number of encoded blocks read ahead, while the current one is uploaded
+const dbfsReadAheadBlocks = 4
+
+// number of attempts to add a single block, before upload fails
+const dbfsBlockAttempts = 3
+
+type dbfsBlock struct {
+	data string
+	size int
+	err  error
+}
+
+// CreateFromReader streams content of the reader to a file on DBFS. Blocks are appended
+// to a handle in the order of add-block calls, so reading and encoding of the next few blocks
+// is pipelined with the upload of the current one, keeping at most few megabytes in memory.
+// Failed block is retried from the last confirmed offset, see uploadBlock.
+func (a DbfsAPI) CreateFromReader(path string, reader io.Reader, overwrite bool) (err error) {
 	handle, err := a.createHandle(path, overwrite)
 	if err != nil {
 		return
 	}
 	defer func() {
 		cerr := a.closeHandle(handle)
-		if cerr != nil {
+		if cerr != nil && err == nil {
 			err = cerr
 		}
 	}()
-	buffer := bytes.NewBuffer(byteArr)
-	for {
-		byteChunk := buffer.Next(1e6)
-		if len(byteChunk) == 0 {
-			break
+	done := make(chan struct{})
+	defer close(done)
+	blocks := make(chan dbfsBlock, dbfsReadAheadBlocks)
+	go readDbfsBlocks(reader, blocks, done)
+	uploaded := 0
+	for block := range blocks {
+		if block.err != nil {
+			return fmt.Errorf("cannot read %s content: %w", path, block.err)
 		}
-		b64Data := base64.StdEncoding.EncodeToString(byteChunk)
-		err = a.addBlock(b64Data, handle)
+		err = a.uploadBlock(path, handle, block, int64(uploaded))
 		if err != nil {
-			return
+			return fmt.Errorf("cannot upload block at %d bytes of %s: %w", uploaded, path, err)
 		}
+		uploaded += block.size
+		log.Printf("[DEBUG] Uploaded %d bytes of %s", uploaded, path)
 	}
+	log.Printf("[INFO] Uploaded %d bytes to %s", uploaded, path)
 	return
 }
 
+// uploadBlock adds the block to the handle, retrying it when the size of the file shows,
+// that the failed request didn't append the block. If the block was appended, despite of
+// the error, upload is resumed from the next block, so that content is not duplicated.
+func (a DbfsAPI) uploadBlock(path string, handle int64, block dbfsBlock, offset int64) error {
+	var err error
+	for attempt := 1; attempt <= dbfsBlockAttempts; attempt++ {
+		err = a.addBlock(block.data, handle)
+		if err == nil || a.context.Err() != nil {
+			return err
+		}
+		status, statusErr := a.Status(path)
+		if statusErr != nil {
+			log.Printf("[WARN] Cannot check size of %s: %s", path, statusErr)
+			return err
+		}
+		switch status.FileSize {
+		case offset + int64(block.size):
+			log.Printf("[INFO] Block at %d bytes of %s was added despite of error: %s", offset, path, err)
+			return nil
+		case offset:
+			log.Printf("[WARN] Retrying block at %d bytes of %s (attempt %d): %s", offset, path, attempt, err)
+		default:
+			// handle is in unknown state and upload has to be started from scratch
+			return err
+		}
+	}
+	return err
+}
+
+// readDbfsBlocks reads and encodes blocks until the reader is exhausted or upload is stopped
+func readDbfsBlocks(reader io.Reader, blocks chan<- dbfsBlock, done <-chan struct{}) {
+	defer close(blocks)
+	buf := make([]byte, dbfsBlockSize)
+	for {
+		n, err := io.ReadFull(reader, buf)
+		if err == io.EOF {
+			return
+		}
+		var block dbfsBlock
+		if err != nil && err != io.ErrUnexpectedEOF {
+			block = dbfsBlock{err: err}
+		} else {
+			block = dbfsBlock{
+				data: base64.StdEncoding.EncodeToString(buf[:n]),
+				size: n,
+			}
+		}
+		select {
+		case blocks <- block:
+		case <-done:
+			return
+		}
+		if block.err != nil || n < len(buf) {
+			return
+		}
+	}
+}
+
 func (a DbfsAPI) createHandle(path string, overwrite bool) (int64, error) {
 	var h Handle
 	err := a.client.Post(a.context, "/dbfs/create", CreateHandle{path, overwrite}, &h)
@@ -159,21 +249,52 @@ type dbfsRequest struct {
 
 // Read returns the contents of a file
 func (a DbfsAPI) Read(path string) (content []byte, err error) {
-	fetchLoop := true
+	var buf bytes.Buffer
+	err = a.ReadTo(path, &buf)
+	return buf.Bytes(), err
+}
+
+// ReadTo streams the contents of a file to the writer
+func (a DbfsAPI) ReadTo(path string, w io.Writer) error {
+	read := 0
+	return a.readChunks(path, func(chunk []byte) error {
+		_, err := w.Write(chunk)
+		read += len(chunk)
+		log.Printf("[DEBUG] Downloaded %d bytes of %s", read, path)
+		return err
+	})
+}
+
+// MD5 returns hex-encoded checksum of a file, without holding its contents in memory
+func (a DbfsAPI) MD5(path string) (string, error) {
+	h := md5.New()
+	err := a.readChunks(path, func(chunk []byte) error {
+		_, err := h.Write(chunk)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+// readChunks sequentially reads file by blocks of 1MB, which is the maximum allowed by API
+func (a DbfsAPI) readChunks(path string, consume func(chunk []byte) error) error {
 	offSet := int64(0)
-	length := int64(1e6)
-	for fetchLoop {
+	length := int64(dbfsBlockSize)
+	for {
 		bytesRead, bytes, err := a.read(path, offSet, length)
 		if err != nil {
-			return content, err
+			return err
+		}
+		if err = consume(bytes); err != nil {
+			return err
 		}
 		if bytesRead == 0 || bytesRead < length {
-			fetchLoop = false
+			return nil
 		}
-		content = append(content, bytes...)
 		offSet += length
 	}
-	return content, err
 }
 
 func (a DbfsAPI) read(path string, offset, length int64) (int64, []byte, error) {
@@ -4,12 +4,17 @@ import (
 	"bytes"
 	"context"
 	"crypto/md5"
+	"encoding/base64"
+	"fmt"
 	"os"
 	"testing"
+	"testing/iotest"
 
 	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func GenString(times int) []byte {
@@ -68,3 +73,207 @@ func TestAccCreateFile(t *testing.T) {
 	assert.NoError(t, err, err)
 	assert.Len(t, items, 3)
 }
+
+func TestCreateFromReader_MultipleBlocks(t *testing.T) {
+	content := bytes.Repeat([]byte("a"), 2500000)
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/dbfs/create",
+			ExpectedRequest: CreateHandle{
+				Path:      "/abc",
+				Overwrite: true,
+			},
+			Response: Handle{123},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/dbfs/add-block",
+			ExpectedRequest: AddBlock{
+				Data:   base64.StdEncoding.EncodeToString(content[:1000000]),
+				Handle: 123,
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/dbfs/add-block",
+			ExpectedRequest: AddBlock{
+				Data:   base64.StdEncoding.EncodeToString(content[1000000:2000000]),
+				Handle: 123,
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/dbfs/add-block",
+			ExpectedRequest: AddBlock{
+				Data:   base64.StdEncoding.EncodeToString(content[2000000:]),
+				Handle: 123,
+			},
+		},
+		{
+			Method:          "POST",
+			Resource:        "/api/2.0/dbfs/close",
+			ExpectedRequest: Handle{123},
+		},
+	})
+	require.NoError(t, err)
+	defer server.Close()
+
+	err = NewDbfsAPI(context.Background(), client).CreateFromReader("/abc",
+		bytes.NewReader(content), true)
+	assert.NoError(t, err)
+}
+
+func TestCreateFromReader_BlockError(t *testing.T) {
+	content := bytes.Repeat([]byte("a"), 1500000)
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/dbfs/create",
+			Response: Handle{123},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/dbfs/add-block",
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/dbfs/add-block",
+			Response: common.APIErrorBody{
+				ErrorCode: "INVALID_REQUEST",
+				Message:   "Internal error happened",
+			},
+			Status:       400,
+			ReuseRequest: true,
+		},
+		{
+			Method:       "GET",
+			Resource:     "/api/2.0/dbfs/get-status?path=%2Fabc",
+			Response:     FileInfo{Path: "/abc", FileSize: 1000000},
+			ReuseRequest: true,
+		},
+		{
+			Method:          "POST",
+			Resource:        "/api/2.0/dbfs/close",
+			ExpectedRequest: Handle{123},
+		},
+	})
+	require.NoError(t, err)
+	defer server.Close()
+
+	err = NewDbfsAPI(context.Background(), client).CreateFromReader("/abc",
+		bytes.NewReader(content), true)
+	assert.EqualError(t, err, "cannot upload block at 1000000 bytes of /abc: Internal error happened")
+}
+
+func TestCreateFromReader_BlockRetried(t *testing.T) {
+	content := bytes.Repeat([]byte("a"), 1500000)
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/dbfs/create",
+			Response: Handle{123},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/dbfs/add-block",
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/dbfs/add-block",
+			Response: common.APIErrorBody{
+				ErrorCode: "INVALID_REQUEST",
+				Message:   "Internal error happened",
+			},
+			Status: 400,
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/dbfs/get-status?path=%2Fabc",
+			Response: FileInfo{Path: "/abc", FileSize: 1000000},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/dbfs/add-block",
+			ExpectedRequest: AddBlock{
+				Data:   base64.StdEncoding.EncodeToString(content[1000000:]),
+				Handle: 123,
+			},
+		},
+		{
+			Method:          "POST",
+			Resource:        "/api/2.0/dbfs/close",
+			ExpectedRequest: Handle{123},
+		},
+	})
+	require.NoError(t, err)
+	defer server.Close()
+
+	err = NewDbfsAPI(context.Background(), client).CreateFromReader("/abc",
+		bytes.NewReader(content), true)
+	assert.NoError(t, err)
+}
+
+func TestCreateFromReader_BlockAddedDespiteError(t *testing.T) {
+	content := bytes.Repeat([]byte("a"), 1500000)
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/dbfs/create",
+			Response: Handle{123},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/dbfs/add-block",
+			Response: common.APIErrorBody{
+				ErrorCode: "INVALID_REQUEST",
+				Message:   "Internal error happened",
+			},
+			Status: 400,
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/dbfs/get-status?path=%2Fabc",
+			Response: FileInfo{Path: "/abc", FileSize: 1000000},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/dbfs/add-block",
+			ExpectedRequest: AddBlock{
+				Data:   base64.StdEncoding.EncodeToString(content[1000000:]),
+				Handle: 123,
+			},
+		},
+		{
+			Method:          "POST",
+			Resource:        "/api/2.0/dbfs/close",
+			ExpectedRequest: Handle{123},
+		},
+	})
+	require.NoError(t, err)
+	defer server.Close()
+
+	err = NewDbfsAPI(context.Background(), client).CreateFromReader("/abc",
+		bytes.NewReader(content), true)
+	assert.NoError(t, err)
+}
+
+func TestCreateFromReader_ReadError(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/dbfs/create",
+			Response: Handle{123},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/dbfs/close",
+		},
+	})
+	require.NoError(t, err)
+	defer server.Close()
+
+	err = NewDbfsAPI(context.Background(), client).CreateFromReader("/abc",
+		iotest.ErrReader(fmt.Errorf("disk is gone")), true)
+	assert.EqualError(t, err, "cannot read /abc content: disk is gone")
+}
@@ -8,6 +8,7 @@ import (
 	"reflect"
 	"regexp"
 	"strings"
+	"sync"
 
 	"github.com/databrickslabs/terraform-provider-databricks/clusters"
 	"github.com/databrickslabs/terraform-provider-databricks/common"
@@ -66,16 +67,29 @@ func (mp MountPoint) Delete() error {
 	return result.Err()
 }
 
+var (
+	secretsRe   = regexp.MustCompile(`"\{\{secrets/([^/]+)/([^\}]+)\}\}"`)
+	sparkConfRe = regexp.MustCompile(`"\{\{sparkconf/([^\}]+)\}\}"`)
+)
+
+// pythonLiteral renders strings, maps and lists of them as Python expression,
+// resolving `{{secrets/scope/key}}` and `{{sparkconf/name}}` references on the cluster
+func pythonLiteral(v interface{}) (string, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	raw = secretsRe.ReplaceAll(raw, []byte(`dbutils.secrets.get("$1", "$2")`))
+	raw = sparkConfRe.ReplaceAll(raw, []byte(`spark.conf.get("$1")`))
+	return string(raw), nil
+}
+
 // Mount mounts object store on workspace
 func (mp MountPoint) Mount(mo Mount, client *common.DatabricksClient) (source string, err error) {
-	extraConfigs, err := json.Marshal(mo.Config(client))
+	extraConfigs, err := pythonLiteral(mo.Config(client))
 	if err != nil {
 		return
 	}
-	secretsRe := regexp.MustCompile(`"\{\{secrets/([^/]+)/([^\}]+)\}\}"`)
-	extraConfigs = secretsRe.ReplaceAll(extraConfigs, []byte(`dbutils.secrets.get("$1", "$2")`))
-	sparkConfRe := regexp.MustCompile(`"\{\{sparkconf/([^\}]+)\}\}"`)
-	extraConfigs = sparkConfRe.ReplaceAll(extraConfigs, []byte(`spark.conf.get("$1")`))
 	command := fmt.Sprintf(`
 		def safe_mount(mount_point, mount_source, configs, encryptionType):
 			for mount in dbutils.fs.mounts():
@@ -99,6 +113,111 @@ func (mp MountPoint) Mount(mo Mount, client *common.DatabricksClient) (source st
 	return result.Text(), result.Err()
 }
 
+// MountInfo describes existing mount point
+type MountInfo struct {
+	MountPoint     string `json:"mount_point"`
+	Source         string `json:"source"`
+	EncryptionType string `json:"encryption_type,omitempty"`
+}
+
+// listMountInfos returns all mounts, that are visible on the cluster
+func listMountInfos(exec common.CommandExecutor, clusterID string) ([]MountInfo, error) {
+	result := exec.Execute(clusterID, "python", `
+		import json
+		dbutils.fs.refreshMounts()
+		dbutils.notebook.exit(json.dumps([{
+			"mount_point": m.mountPoint,
+			"source": m.source,
+			"encryption_type": m.encryptionType,
+		} for m in dbutils.fs.mounts()]))
+	`)
+	if result.Failed() {
+		return nil, result.Err()
+	}
+	var mounts []MountInfo
+	if err := json.Unmarshal([]byte(result.Text()), &mounts); err != nil {
+		return nil, fmt.Errorf("cannot parse mounts of cluster %s: %w", clusterID, err)
+	}
+	return mounts, nil
+}
+
+// listMounts returns sources of all mounts on the cluster by their mount points
+func listMounts(exec common.CommandExecutor, clusterID string) (map[string]string, error) {
+	mountInfos, err := listMountInfos(exec, clusterID)
+	if err != nil {
+		return nil, err
+	}
+	mounts := map[string]string{}
+	for _, mi := range mountInfos {
+		mounts[mi.MountPoint] = mi.Source
+	}
+	return mounts, nil
+}
+
+type clusterMounts struct {
+	once   sync.Once
+	mounts map[string]string
+	err    error
+}
+
+// mountsCache keeps mounts listed once per cluster, so that refresh of
+// many mount resources runs a single command on the cluster
+var mountsCache = struct {
+	sync.Mutex
+	clusters map[string]*clusterMounts
+}{clusters: map[string]*clusterMounts{}}
+
+func mountsCacheKey(client *common.DatabricksClient, clusterID string) string {
+	return client.Host + "/" + clusterID
+}
+
+// cachedMounts lists mounts of the cluster, unless they were already listed
+func cachedMounts(client *common.DatabricksClient, exec common.CommandExecutor,
+	clusterID string) (map[string]string, error) {
+	key := mountsCacheKey(client, clusterID)
+	mountsCache.Lock()
+	cm, ok := mountsCache.clusters[key]
+	if !ok {
+		cm = &clusterMounts{}
+		mountsCache.clusters[key] = cm
+	}
+	mountsCache.Unlock()
+	cm.once.Do(func() {
+		cm.mounts, cm.err = listMounts(exec, clusterID)
+		if cm.err != nil {
+			// failures are not cached, so that next read retries
+			forgetMounts(client, clusterID)
+		}
+	})
+	return cm.mounts, cm.err
+}
+
+// forgetMounts invalidates cached mounts of the cluster
+func forgetMounts(client *common.DatabricksClient, clusterID string) {
+	mountsCache.Lock()
+	defer mountsCache.Unlock()
+	delete(mountsCache.clusters, mountsCacheKey(client, clusterID))
+}
+
+// withRefreshViaCluster adds `refresh_via_cluster` flag, that can be changed in-place
+func withRefreshViaCluster(r *schema.Resource) *schema.Resource {
+	r.Schema["refresh_via_cluster"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	r.UpdateContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		// all other fields are force_new
+		return nil
+	}
+	return r
+}
+
+// trustMountState is true, when mount source is known and cluster doesn't have to be started to verify it
+func trustMountState(d *schema.ResourceData) bool {
+	return !d.Get("refresh_via_cluster").(bool) && d.Get("source").(string) != ""
+}
+
 func commonMountResource(tpl Mount, s map[string]*schema.Schema) *schema.Resource {
 	resource := &schema.Resource{
 		SchemaVersion: 2,
@@ -111,7 +230,7 @@ func commonMountResource(tpl Mount, s map[string]*schema.Schema) *schema.Resourc
 	resource.Importer = &schema.ResourceImporter{
 		StateContext: schema.ImportStatePassthroughContext,
 	}
-	return resource
+	return withRefreshViaCluster(resource)
 }
 
 func deprecatedMountTesource(r *schema.Resource) *schema.Resource {
@@ -237,6 +356,7 @@ func mountCreate(tpl interface{}, r *schema.Resource) func(context.Context, *sch
 		client := m.(*common.DatabricksClient)
 		log.Printf("[INFO] Mounting %s at /mnt/%s", mountConfig.Source(), d.Id())
 		source, err := mountPoint.Mount(mountConfig, client)
+		forgetMounts(client, mountPoint.ClusterID)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -265,13 +385,38 @@ func readMountSource(ctx context.Context, mp MountPoint, d *schema.ResourceData)
 	return nil
 }
 
+// reads and sets source of the mount from mounts, listed once per cluster
+func readCachedMountSource(client *common.DatabricksClient, mp MountPoint, d *schema.ResourceData) diag.Diagnostics {
+	mounts, err := cachedMounts(client, mp.Exec, mp.ClusterID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	source, ok := mounts["/mnt/"+mp.Name]
+	if !ok {
+		log.Printf("[INFO] /mnt/%s is not mounted", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err = d.Set("source", source); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
 // return resource reader function
 func mountRead(tpl Mount, r *schema.Resource) schema.ReadContextFunc {
 	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		if trustMountState(d) {
+			log.Printf("[DEBUG] Using /mnt/%s source from state", d.Id())
+			return nil
+		}
 		_, mp, err := mountCluster(ctx, tpl, d, m, r)
 		if err != nil {
 			return diag.FromErr(err)
 		}
+		if d.Get("refresh_via_cluster").(bool) {
+			return readCachedMountSource(m.(*common.DatabricksClient), mp, d)
+		}
 		return readMountSource(ctx, mp, d)
 	}
 }
@@ -284,7 +429,9 @@ func mountDelete(tpl Mount, r *schema.Resource) schema.DeleteContextFunc {
 			return diag.FromErr(err)
 		}
 		log.Printf("[INFO] Unmounting /mnt/%s", d.Id())
-		if err = mp.Delete(); err != nil {
+		err = mp.Delete()
+		forgetMounts(m.(*common.DatabricksClient), mp.ClusterID)
+		if err != nil {
 			return diag.FromErr(err)
 		}
 		return nil
@@ -257,3 +257,42 @@ func TestOldMountImplementations(t *testing.T) {
 	assert.Nil(t, m4.ValidateAndApplyDefaults(nil, nil))
 
 }
+
+func TestCachedMounts(t *testing.T) {
+	c := common.DatabricksClient{
+		Host:  ".",
+		Token: ".",
+	}
+	err := c.Configure()
+	assert.NoError(t, err)
+	calls := 0
+	c.WithCommandMock(func(commandStr string) common.CommandResults {
+		calls++
+		if calls == 1 {
+			return common.CommandResults{
+				ResultType: "error",
+				Summary:    "Cluster is busy",
+			}
+		}
+		return common.CommandResults{
+			ResultType: "text",
+			Data:       `[{"mount_point": "/mnt/a", "source": "s3a://a"}, {"mount_point": "/mnt/b", "source": "s3a://b"}]`,
+		}
+	})
+	exec := c.CommandExecutor(context.Background())
+
+	_, err = cachedMounts(&c, exec, "cached_cluster")
+	assert.EqualError(t, err, "Cluster is busy")
+
+	for _, name := range []string{"/mnt/a", "/mnt/b"} {
+		mounts, err := cachedMounts(&c, exec, "cached_cluster")
+		assert.NoError(t, err)
+		assert.Contains(t, mounts, name)
+	}
+	assert.Equal(t, 2, calls, "failed listing is retried, successful one is reused")
+
+	forgetMounts(&c, "cached_cluster")
+	_, err = cachedMounts(&c, exec, "cached_cluster")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+}
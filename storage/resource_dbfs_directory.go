@@ -0,0 +1,256 @@
+package storage
+
+import (
+	"context"
+	"crypto/md5"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/workspace"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// localDirectory describes files of local folder, that are synced to DBFS
+type localDirectory struct {
+	Source  string
+	Include []string
+	Exclude []string
+}
+
+// matchesAny checks relative slash-separated path against glob patterns. Patterns
+// without slashes are matched against file names in any subfolder.
+func matchesAny(rel string, patterns []string) bool {
+	for _, pattern := range patterns {
+		name := rel
+		if !strings.Contains(pattern, "/") {
+			name = path.Base(rel)
+		}
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Checksums returns MD5 checksums of all included files by their relative paths
+func (ld localDirectory) Checksums() (map[string]string, error) {
+	checksums := map[string]string{}
+	err := filepath.Walk(ld.Source, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(ld.Source, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if len(ld.Include) > 0 && !matchesAny(rel, ld.Include) {
+			return nil
+		}
+		if matchesAny(rel, ld.Exclude) {
+			return nil
+		}
+		checksums[rel], err = fileChecksum(p)
+		return err
+	})
+	return checksums, err
+}
+
+func fileChecksum(name string) (string, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := md5.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+func newLocalDirectory(d interface {
+	Get(string) interface{}
+}) localDirectory {
+	ld := localDirectory{
+		Source: d.Get("source").(string),
+	}
+	for _, v := range d.Get("include").(*schema.Set).List() {
+		ld.Include = append(ld.Include, v.(string))
+	}
+	for _, v := range d.Get("exclude").(*schema.Set).List() {
+		ld.Exclude = append(ld.Exclude, v.(string))
+	}
+	return ld
+}
+
+func stringMap(v interface{}) map[string]string {
+	m := map[string]string{}
+	for k, v := range v.(map[string]interface{}) {
+		m[k] = v.(string)
+	}
+	return m
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := []string{}
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// syncDirectory uploads changed files and deletes removed ones, returning
+// checksums of files, that are currently on DBFS
+func syncDirectory(dbfsAPI DbfsAPI, ld localDirectory, prefix string,
+	old, new map[string]string) (map[string]string, error) {
+	synced := map[string]string{}
+	for k, v := range old {
+		synced[k] = v
+	}
+	for _, rel := range sortedKeys(new) {
+		if old[rel] == new[rel] {
+			continue
+		}
+		checksum, err := uploadFile(dbfsAPI, filepath.Join(ld.Source, filepath.FromSlash(rel)),
+			path.Join(prefix, rel))
+		if err != nil {
+			return synced, fmt.Errorf("cannot upload %s: %w", rel, err)
+		}
+		synced[rel] = checksum
+	}
+	for _, rel := range sortedKeys(old) {
+		if _, ok := new[rel]; ok {
+			continue
+		}
+		err := dbfsAPI.Delete(path.Join(prefix, rel), false)
+		if err != nil && !common.IsMissing(err) {
+			return synced, fmt.Errorf("cannot delete %s: %w", rel, err)
+		}
+		delete(synced, rel)
+	}
+	return synced, nil
+}
+
+// ResourceDBFSDirectory syncs local folder to DBFS prefix
+func ResourceDBFSDirectory() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"source": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		// same validation of DBFS path, as for databricks_dbfs_file
+		"path": workspace.FileContentSchema(map[string]*schema.Schema{})["path"],
+		"include": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"exclude": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"files": {
+			Type:     schema.TypeMap,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"dbfs_path": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+	return common.Resource{
+		Schema: s,
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, c interface{}) error {
+			if !d.NewValueKnown("source") || !d.NewValueKnown("include") || !d.NewValueKnown("exclude") {
+				return d.SetNewComputed("files")
+			}
+			checksums, err := newLocalDirectory(d).Checksums()
+			if err != nil {
+				return err
+			}
+			if reflect.DeepEqual(checksums, stringMap(d.Get("files"))) {
+				return nil
+			}
+			return d.SetNew("files", checksums)
+		},
+		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			prefix := d.Get("path").(string)
+			dbfsAPI := NewDbfsAPI(ctx, c)
+			if err := dbfsAPI.Mkdirs(prefix); err != nil {
+				return err
+			}
+			ld := newLocalDirectory(d)
+			checksums, err := ld.Checksums()
+			if err != nil {
+				return err
+			}
+			synced, err := syncDirectory(dbfsAPI, ld, prefix, map[string]string{}, checksums)
+			if len(synced) > 0 || err == nil {
+				// partially uploaded files are removed on destroy
+				d.SetId(prefix)
+				d.Set("files", synced)
+			}
+			return err
+		},
+		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			remote, err := NewDbfsAPI(ctx, c).List(d.Id(), true)
+			if err != nil {
+				return err
+			}
+			exists := map[string]bool{}
+			for _, fi := range remote {
+				exists[strings.TrimPrefix(fi.Path, strings.TrimSuffix(d.Id(), "/")+"/")] = true
+			}
+			files := stringMap(d.Get("files"))
+			for rel := range files {
+				if !exists[rel] {
+					log.Printf("[INFO] %s was removed from %s", rel, d.Id())
+					delete(files, rel)
+				}
+			}
+			d.Set("path", d.Id())
+			d.Set("dbfs_path", fmt.Sprint("dbfs:", d.Id()))
+			d.Set("files", files)
+			return nil
+		},
+		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			// files in the state are the ones, that were synced last time
+			o, _ := d.GetChange("files")
+			old := stringMap(o)
+			ld := newLocalDirectory(d)
+			checksums, err := ld.Checksums()
+			if err != nil {
+				return err
+			}
+			synced, err := syncDirectory(NewDbfsAPI(ctx, c), ld, d.Id(), old, checksums)
+			d.Set("files", synced)
+			return err
+		},
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			// only managed files are removed, so that files of other tools are kept
+			dbfsAPI := NewDbfsAPI(ctx, c)
+			for _, rel := range sortedKeys(stringMap(d.Get("files"))) {
+				err := dbfsAPI.Delete(path.Join(d.Id(), rel), false)
+				if err != nil && !common.IsMissing(err) {
+					return err
+				}
+			}
+			return nil
+		},
+	}.ToResource()
+}
@@ -0,0 +1,239 @@
+package storage
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func localTestDirectory(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0755))
+		require.NoError(t, ioutil.WriteFile(p, []byte(content), 0644))
+	}
+	return dir
+}
+
+func uploadFixtures(path, b64 string) []qa.HTTPFixture {
+	return []qa.HTTPFixture{
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/dbfs/create",
+			ExpectedRequest: CreateHandle{
+				Path:      path,
+				Overwrite: true,
+			},
+			Response: Handle{123},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/dbfs/add-block",
+			ExpectedRequest: AddBlock{
+				Data:   b64,
+				Handle: 123,
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/dbfs/close",
+		},
+	}
+}
+
+func TestResourceDBFSDirectoryCreate(t *testing.T) {
+	dir := localTestDirectory(t, map[string]string{
+		"a.py":     "abc",
+		"b.txt":    "xyz",
+		"sub/c.py": "abc",
+		"sub/d.py": "xyz",
+	})
+	fixtures := []qa.HTTPFixture{
+		{
+			Method:          "POST",
+			Resource:        "/api/2.0/dbfs/mkdirs",
+			ExpectedRequest: map[string]string{"path": "/scripts"},
+		},
+	}
+	fixtures = append(fixtures, uploadFixtures("/scripts/a.py", "YWJj")...)
+	fixtures = append(fixtures, uploadFixtures("/scripts/sub/c.py", "YWJj")...)
+	fixtures = append(fixtures, qa.HTTPFixture{
+		Method:   "GET",
+		Resource: "/api/2.0/dbfs/list?path=%2Fscripts",
+		Response: FileList{
+			Files: []FileInfo{
+				{Path: "/scripts/a.py", FileSize: 3},
+				{Path: "/scripts/sub", IsDir: true},
+			},
+		},
+	}, qa.HTTPFixture{
+		Method:   "GET",
+		Resource: "/api/2.0/dbfs/list?path=%2Fscripts%2Fsub",
+		Response: FileList{
+			Files: []FileInfo{
+				{Path: "/scripts/sub/c.py", FileSize: 3},
+			},
+		},
+	})
+	d, err := qa.ResourceFixture{
+		Fixtures: fixtures,
+		Resource: ResourceDBFSDirectory(),
+		Create:   true,
+		HCL: fmt.Sprintf(`
+		source = "%s"
+		path = "/scripts"
+		include = ["*.py"]
+		exclude = ["sub/d.*"]
+		`, filepath.ToSlash(dir)),
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "/scripts", d.Id())
+	assert.Equal(t, "dbfs:/scripts", d.Get("dbfs_path"))
+	assert.Equal(t, map[string]interface{}{
+		"a.py":     "900150983cd24fb0d6963f7d28e17f72",
+		"sub/c.py": "900150983cd24fb0d6963f7d28e17f72",
+	}, d.Get("files"))
+}
+
+func TestResourceDBFSDirectoryUpdate(t *testing.T) {
+	dir := localTestDirectory(t, map[string]string{
+		"a.py": "xyz",
+		"b.py": "abc",
+	})
+	fixtures := uploadFixtures("/scripts/a.py", "eHl6")
+	fixtures = append(fixtures, qa.HTTPFixture{
+		Method:   "POST",
+		Resource: "/api/2.0/dbfs/delete",
+		ExpectedRequest: dbfsRequest{
+			Path: "/scripts/gone.py",
+		},
+	}, qa.HTTPFixture{
+		Method:   "GET",
+		Resource: "/api/2.0/dbfs/list?path=%2Fscripts",
+		Response: FileList{
+			Files: []FileInfo{
+				{Path: "/scripts/a.py", FileSize: 3},
+				{Path: "/scripts/b.py", FileSize: 3},
+			},
+		},
+	})
+	d, err := qa.ResourceFixture{
+		Fixtures: fixtures,
+		Resource: ResourceDBFSDirectory(),
+		Update:   true,
+		ID:       "/scripts",
+		InstanceState: map[string]string{
+			"source":  filepath.ToSlash(dir),
+			"path":    "/scripts",
+			"files.%": "3",
+			// changed locally
+			"files.a.py": "900150983cd24fb0d6963f7d28e17f72",
+			// not changed
+			"files.b.py": "900150983cd24fb0d6963f7d28e17f72",
+			// removed locally
+			"files.gone.py": "900150983cd24fb0d6963f7d28e17f72",
+		},
+		HCL: fmt.Sprintf(`
+		source = "%s"
+		path = "/scripts"
+		`, filepath.ToSlash(dir)),
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, map[string]interface{}{
+		"a.py": "d16fb36f0911f878998c136191af705e",
+		"b.py": "900150983cd24fb0d6963f7d28e17f72",
+	}, d.Get("files"))
+}
+
+func TestResourceDBFSDirectoryRead_RemovedRemotely(t *testing.T) {
+	dir := localTestDirectory(t, map[string]string{
+		"a.py": "abc",
+	})
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/dbfs/list?path=%2Fscripts",
+				Response: FileList{
+					Files: []FileInfo{
+						{Path: "/scripts/a.py", FileSize: 3},
+					},
+				},
+			},
+		},
+		Resource: ResourceDBFSDirectory(),
+		Read:     true,
+		New:      true,
+		ID:       "/scripts",
+		InstanceState: map[string]string{
+			"source":     filepath.ToSlash(dir),
+			"path":       "/scripts",
+			"files.%":    "2",
+			"files.a.py": "900150983cd24fb0d6963f7d28e17f72",
+			"files.b.py": "900150983cd24fb0d6963f7d28e17f72",
+		},
+		HCL: fmt.Sprintf(`
+		source = "%s"
+		path = "/scripts"
+		`, filepath.ToSlash(dir)),
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, map[string]interface{}{
+		"a.py": "900150983cd24fb0d6963f7d28e17f72",
+	}, d.Get("files"))
+}
+
+func TestResourceDBFSDirectoryDelete(t *testing.T) {
+	dir := localTestDirectory(t, map[string]string{
+		"a.py": "abc",
+	})
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/dbfs/delete",
+				ExpectedRequest: dbfsRequest{
+					Path: "/scripts/a.py",
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/dbfs/delete",
+				ExpectedRequest: dbfsRequest{
+					Path: "/scripts/sub/b.py",
+				},
+				Status: 404,
+			},
+		},
+		Resource: ResourceDBFSDirectory(),
+		Delete:   true,
+		ID:       "/scripts",
+		InstanceState: map[string]string{
+			"source":         filepath.ToSlash(dir),
+			"path":           "/scripts",
+			"files.%":        "2",
+			"files.a.py":     "900150983cd24fb0d6963f7d28e17f72",
+			"files.sub/b.py": "900150983cd24fb0d6963f7d28e17f72",
+		},
+		HCL: fmt.Sprintf(`
+		source = "%s"
+		path = "/scripts"
+		`, filepath.ToSlash(dir)),
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "/scripts", d.Id())
+}
+
+func TestMatchesAny(t *testing.T) {
+	assert.True(t, matchesAny("a/b/c.py", []string{"*.py"}))
+	assert.True(t, matchesAny("a/b/c.py", []string{"a/*/c.py"}))
+	assert.False(t, matchesAny("a/b/c.py", []string{"a/*.py"}))
+	assert.False(t, matchesAny("a/b/c.py", []string{}))
+}
@@ -2,7 +2,11 @@ package storage
 
 import (
 	"context"
+	"crypto/md5"
 	"fmt"
+	"io"
+	"log"
+	"os"
 
 	"github.com/databrickslabs/terraform-provider-databricks/common"
 
@@ -12,20 +16,41 @@ import (
 
 // ResourceDBFSFile manages files on DBFS
 func ResourceDBFSFile() *schema.Resource {
+	s := workspace.FileContentSchema(map[string]*schema.Schema{
+		"file_size": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"dbfs_path": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"verify_checksum": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+	})
+	// content changes require file to be uploaded again, but checksum
+	// verification could be toggled without touching the file
+	for _, k := range []string{"md5", "content_base64", "source"} {
+		s[k].ForceNew = true
+	}
 	return common.Resource{
 		SchemaVersion: 1,
-		Schema: workspace.FileContentSchema(map[string]*schema.Schema{
-			"file_size": {
-				Type:     schema.TypeInt,
-				Computed: true,
-			},
-			"dbfs_path": {
-				Type:     schema.TypeString,
-				Computed: true,
-			},
-		}),
+		Schema:        s,
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			path := d.Get("path").(string)
+			if source := d.Get("source").(string); source != "" {
+				// large artifacts, like JARs, are streamed without reading them into memory
+				checksum, err := uploadFile(NewDbfsAPI(ctx, c), source, path)
+				if err != nil {
+					return err
+				}
+				d.Set("md5", checksum)
+				d.SetId(path)
+				return nil
+			}
 			content, err := workspace.ReadContent(d)
 			if err != nil {
 				return err
@@ -45,6 +70,23 @@ func ResourceDBFSFile() *schema.Resource {
 			d.Set("path", fileInfo.Path)
 			d.Set("dbfs_path", fmt.Sprint("dbfs:", fileInfo.Path))
 			d.Set("file_size", fileInfo.FileSize)
+			if !d.Get("verify_checksum").(bool) {
+				return nil
+			}
+			// file could be overwritten outside of terraform with the content of the same size,
+			// so we compare checksums and let `md5` diff to recreate the file
+			remoteMD5, err := dbfsAPI.MD5(d.Id())
+			if err != nil {
+				return err
+			}
+			if remoteMD5 != d.Get("md5").(string) {
+				log.Printf("[INFO] Checksum of %s changed from %s to %s", d.Id(), d.Get("md5"), remoteMD5)
+				d.Set("md5", remoteMD5)
+			}
+			return nil
+		},
+		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			// only `verify_checksum` could be changed in-place
 			return nil
 		},
 		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
@@ -59,3 +101,18 @@ func ResourceDBFSFile() *schema.Resource {
 		},
 	}.ToResource()
 }
+
+// uploadFile streams local file to DBFS and returns its checksum, calculated in the same pass
+func uploadFile(dbfsAPI DbfsAPI, source, path string) (string, error) {
+	f, err := os.Open(source)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := md5.New()
+	err = dbfsAPI.CreateFromReader(path, io.TeeReader(f, h), true)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
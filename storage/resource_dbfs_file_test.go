@@ -217,3 +217,104 @@ func TestDBFSFileRead_IsMissingResource(t *testing.T) {
 		},
 	}.ApplyNoError(t)
 }
+
+func TestDBFSFileRead_ChecksumMismatch(t *testing.T) {
+	path := "/abc"
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			getBaseDBFSFileGetStatusFixtures(path, false, false)[0],
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/dbfs/read?length=1000000&path=%2Fabc",
+				Response: ReadResponse{
+					BytesRead: 3,
+					Data:      "eHl6",
+				},
+			},
+		},
+		Resource:    ResourceDBFSFile(),
+		Read:        true,
+		New:         true,
+		RequiresNew: true,
+		ID:          path,
+		HCL: `
+		path = "/abc"
+		content_base64 = "YWJj"
+		verify_checksum = true
+		`,
+		InstanceState: map[string]string{
+			"path":            path,
+			"content_base64":  "YWJj",
+			"md5":             "900150983cd24fb0d6963f7d28e17f72",
+			"verify_checksum": "true",
+		},
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "d16fb36f0911f878998c136191af705e", d.Get("md5"))
+}
+
+func TestDBFSFileRead_ChecksumMatches(t *testing.T) {
+	path := "/abc"
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			getBaseDBFSFileGetStatusFixtures(path, false, false)[0],
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/dbfs/read?length=1000000&path=%2Fabc",
+				Response: ReadResponse{
+					BytesRead: 3,
+					Data:      "YWJj",
+				},
+			},
+		},
+		Resource: ResourceDBFSFile(),
+		Read:     true,
+		New:      true,
+		ID:       path,
+		HCL: `
+		path = "/abc"
+		content_base64 = "YWJj"
+		verify_checksum = true
+		`,
+		InstanceState: map[string]string{
+			"path":            path,
+			"content_base64":  "YWJj",
+			"md5":             "900150983cd24fb0d6963f7d28e17f72",
+			"verify_checksum": "true",
+		},
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "900150983cd24fb0d6963f7d28e17f72", d.Get("md5"))
+}
+
+func TestDBFSFileUpdate_VerifyChecksumInPlace(t *testing.T) {
+	path := "/abc"
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			getBaseDBFSFileGetStatusFixtures(path, false, false)[0],
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/dbfs/read?length=1000000&path=%2Fabc",
+				Response: ReadResponse{
+					BytesRead: 3,
+					Data:      "YWJj",
+				},
+			},
+		},
+		Resource: ResourceDBFSFile(),
+		Update:   true,
+		ID:       path,
+		HCL: `
+		path = "/abc"
+		content_base64 = "YWJj"
+		verify_checksum = true
+		`,
+		InstanceState: map[string]string{
+			"path":           path,
+			"content_base64": "YWJj",
+			"md5":            "900150983cd24fb0d6963f7d28e17f72",
+		},
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, true, d.Get("verify_checksum"))
+}
@@ -64,6 +64,10 @@ func ResourceDatabricksMount() *schema.Resource {
 		return mountCreate(tpl, r)(ctx, d, m)
 	}
 	r.ReadContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		if trustMountState(d) {
+			// no need to check the mounting cluster
+			return nil
+		}
 		if err := preprocessResourceData(ctx, d, scm, m); err != nil {
 			return diag.FromErr(err)
 		}
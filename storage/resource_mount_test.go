@@ -369,6 +369,130 @@ func TestResourceAwsS3MountGenericRead_NotFound(t *testing.T) {
 	}.ApplyNoError(t)
 }
 
+func TestResourceAwsS3MountGenericRead_FromState(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Resource: ResourceDatabricksMount(),
+		CommandMock: func(commandStr string) common.CommandResults {
+			t.Fatalf("Unexpected command:\n%s", commandStr)
+			return common.CommandResults{}
+		},
+		State: map[string]interface{}{
+			"cluster_id": "this_cluster",
+			"name":       "this_mount",
+			"s3": []interface{}{map[string]interface{}{
+				"bucket_name": testS3BucketName,
+			}},
+		},
+		InstanceState: map[string]string{
+			"cluster_id":       "this_cluster",
+			"name":             "this_mount",
+			"source":           testS3BucketPath,
+			"s3.#":             "1",
+			"s3.0.bucket_name": testS3BucketName,
+		},
+		ID:   "this_mount",
+		Read: true,
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, "this_mount", d.Id())
+	assert.Equal(t, testS3BucketPath, d.Get("source"))
+}
+
+func TestResourceAwsS3MountGenericRead_RefreshViaCluster(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=this_cluster",
+				Response: clusters.ClusterInfo{
+					State: clusters.ClusterStateRunning,
+					AwsAttributes: &clusters.AwsAttributes{
+						InstanceProfileArn: "abc",
+					},
+				},
+			},
+		},
+		Resource: ResourceDatabricksMount(),
+		CommandMock: func(commandStr string) common.CommandResults {
+			trunc := internal.TrimLeadingWhitespace(commandStr)
+			t.Logf("Received command:\n%s", trunc)
+			assert.Contains(t, trunc, "dbutils.fs.mounts()")
+			assert.NotContains(t, trunc, "this_mount")
+			return common.CommandResults{
+				ResultType: "text",
+				Data:       `[{"mount_point": "/mnt/this_mount", "source": "s3a://renamed"}, {"mount_point": "/mnt/other", "source": "s3a://other"}]`,
+			}
+		},
+		State: map[string]interface{}{
+			"cluster_id":          "this_cluster",
+			"name":                "this_mount",
+			"refresh_via_cluster": true,
+			"s3": []interface{}{map[string]interface{}{
+				"bucket_name": testS3BucketName,
+			}},
+		},
+		InstanceState: map[string]string{
+			"cluster_id":          "this_cluster",
+			"name":                "this_mount",
+			"source":              testS3BucketPath,
+			"refresh_via_cluster": "true",
+			"s3.#":                "1",
+			"s3.0.bucket_name":    testS3BucketName,
+		},
+		ID:   "this_mount",
+		Read: true,
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, "this_mount", d.Id())
+	assert.Equal(t, "s3a://renamed", d.Get("source"))
+}
+
+func TestResourceAwsS3MountGenericRead_RefreshViaClusterNotFound(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=this_cluster",
+				Response: clusters.ClusterInfo{
+					State: clusters.ClusterStateRunning,
+					AwsAttributes: &clusters.AwsAttributes{
+						InstanceProfileArn: "abc",
+					},
+				},
+			},
+		},
+		Resource: ResourceDatabricksMount(),
+		CommandMock: func(commandStr string) common.CommandResults {
+			return common.CommandResults{
+				ResultType: "text",
+				Data:       `[{"mount_point": "/mnt/other", "source": "s3a://other"}]`,
+			}
+		},
+		State: map[string]interface{}{
+			"cluster_id":          "this_cluster",
+			"name":                "this_mount",
+			"refresh_via_cluster": true,
+			"s3": []interface{}{map[string]interface{}{
+				"bucket_name": testS3BucketName,
+			}},
+		},
+		InstanceState: map[string]string{
+			"cluster_id":          "this_cluster",
+			"name":                "this_mount",
+			"source":              testS3BucketPath,
+			"refresh_via_cluster": "true",
+			"s3.#":                "1",
+			"s3.0.bucket_name":    testS3BucketName,
+		},
+		ID:          "this_mount",
+		Read:        true,
+		Removed:     true,
+		RequiresNew: true,
+	}.ApplyNoError(t)
+}
+
 func TestResourceAwsS3MountGenericRead_Error(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
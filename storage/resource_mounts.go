@@ -0,0 +1,481 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/databrickslabs/terraform-provider-databricks/clusters"
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// MountsConfig describes many mounts, that are reconciled on the same cluster
+type MountsConfig struct {
+	ClusterID         string            `json:"cluster_id,omitempty" tf:"computed"`
+	RefreshViaCluster bool              `json:"refresh_via_cluster,omitempty"`
+	Mounts            []GenericMount    `json:"mount"`
+	Sources           map[string]string `json:"sources,omitempty" tf:"computed"`
+}
+
+// mountResult is outcome of (un)mounting of a single mount point
+type mountResult struct {
+	Source string `json:"source,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// mountCommand is what is sent to the cluster for every mount
+type mountCommand struct {
+	MountPoint     string            `json:"mount_point"`
+	Source         string            `json:"source"`
+	Configs        map[string]string `json:"configs"`
+	EncryptionType string            `json:"encryption_type"`
+}
+
+// reconcileMounts mounts and unmounts everything within a single command. Mount points
+// in remount are re-mounted even if their source hasn't changed.
+func reconcileMounts(exec common.CommandExecutor, client *common.DatabricksClient, clusterID string,
+	mounts []GenericMount, remount, unmount []string) (map[string]mountResult, error) {
+	commands := []mountCommand{}
+	for _, m := range mounts {
+		configs := m.Config(client)
+		if configs == nil {
+			// nil map is marshalled to null
+			configs = map[string]string{}
+		}
+		commands = append(commands, mountCommand{
+			MountPoint:     "/mnt/" + m.Name(),
+			Source:         m.Source(),
+			Configs:        configs,
+			EncryptionType: m.EncryptionType,
+		})
+	}
+	mountsLiteral, err := pythonLiteral(commands)
+	if err != nil {
+		return nil, err
+	}
+	remountLiteral, err := pythonLiteral(remount)
+	if err != nil {
+		return nil, err
+	}
+	unmountLiteral, err := pythonLiteral(unmount)
+	if err != nil {
+		return nil, err
+	}
+	result := exec.Execute(clusterID, "python", fmt.Sprintf(`
+		import json
+		mounts = %s
+		remount = %s
+		unmount = %s
+		results = {}
+		dbutils.fs.refreshMounts()
+		current = {m.mountPoint: m.source for m in dbutils.fs.mounts()}
+		for mount_point in unmount:
+			try:
+				if mount_point in current:
+					dbutils.fs.unmount(mount_point)
+				results[mount_point] = {}
+			except Exception as e:
+				results[mount_point] = {"error": str(e)}
+		for m in mounts:
+			mount_point = m["mount_point"]
+			try:
+				if mount_point in current and (mount_point in remount or current[mount_point] != m["source"]):
+					dbutils.fs.unmount(mount_point)
+					del current[mount_point]
+				if mount_point not in current:
+					try:
+						dbutils.fs.mount(m["source"], mount_point, extra_configs=m["configs"], encryption_type=m["encryption_type"])
+						dbutils.fs.refreshMounts()
+						dbutils.fs.ls(mount_point)
+					except Exception as e:
+						try:
+							dbutils.fs.unmount(mount_point)
+						except Exception as e2:
+							print("Failed to unmount", e2)
+						raise e
+				results[mount_point] = {"source": m["source"]}
+			except Exception as e:
+				results[mount_point] = {"error": str(e)}
+		dbutils.fs.refreshMounts()
+		dbutils.notebook.exit(json.dumps(results))
+	`, mountsLiteral, remountLiteral, unmountLiteral)) // lgtm[go/unsafe-quoting]
+	if result.Failed() {
+		return nil, result.Err()
+	}
+	var results map[string]mountResult
+	if err = json.Unmarshal([]byte(result.Text()), &results); err != nil {
+		return nil, fmt.Errorf("cannot parse mount results: %w", err)
+	}
+	return results, nil
+}
+
+// mountErrors combines errors of all failed mount points in a stable order
+func mountErrors(results map[string]mountResult) error {
+	failed := []string{}
+	for mountPoint, r := range results {
+		if r.Error != "" {
+			failed = append(failed, fmt.Sprintf("%s: %s", mountPoint, r.Error))
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	sort.Strings(failed)
+	return fmt.Errorf("%d mount points failed: %s", len(failed), strings.Join(failed, "; "))
+}
+
+// mountFailuresKey holds failures of mount points, that are reported as warnings
+type mountFailuresKey struct{}
+
+// recordMountFailures keeps errors of failed mount points for withMountWarnings
+func recordMountFailures(ctx context.Context, results map[string]mountResult) {
+	failures, ok := ctx.Value(mountFailuresKey{}).(map[string]string)
+	if !ok {
+		return
+	}
+	for mountPoint, r := range results {
+		if r.Error != "" {
+			log.Printf("[WARN] %s failed: %s", mountPoint, r.Error)
+			failures[mountPoint] = r.Error
+		}
+	}
+}
+
+// withMountWarnings reports failed mount points as warnings, so that successful mounts
+// are kept and only failed ones are retried during the next apply
+func withMountWarnings(crud func(context.Context, *schema.ResourceData,
+	interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		failures := map[string]string{}
+		diags := crud(context.WithValue(ctx, mountFailuresKey{}, failures), d, m)
+		mountPoints := []string{}
+		for mountPoint := range failures {
+			mountPoints = append(mountPoints, mountPoint)
+		}
+		sort.Strings(mountPoints)
+		for _, mountPoint := range mountPoints {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("%s failed", mountPoint),
+				Detail:   failures[mountPoint],
+			})
+		}
+		return diags
+	}
+}
+
+// mountsID is comma-separated names of mount points, which are unique within the workspace
+func mountsID(mounts []GenericMount) string {
+	names := []string{}
+	for _, m := range mounts {
+		names = append(names, m.Name())
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+// applyMountDefaults infers names, storage accounts and tenants the same way,
+// as databricks_mount does for a single mount
+func applyMountDefaults(mounts []GenericMount, client *common.DatabricksClient) error {
+	scm := common.StructToSchema(GenericMount{}, nil)
+	seen := map[string]bool{}
+	for i := range mounts {
+		m := &mounts[i]
+		configured := 0
+		if m.URI != "" {
+			configured++
+		}
+		for _, block := range []interface{}{m.Abfs, m.S3, m.Adl, m.Wasb, m.Gs} {
+			if !reflect.ValueOf(block).IsNil() {
+				configured++
+			}
+		}
+		if configured > 1 {
+			return fmt.Errorf("mount #%d: only one of uri, abfs, adl, gs, s3 or wasb could be specified", i)
+		}
+		d := (&schema.Resource{Schema: scm}).Data(nil)
+		d.MarkNewResource()
+		if err := common.StructToData(*m, scm, d); err != nil {
+			return err
+		}
+		if err := m.ValidateAndApplyDefaults(d, client); err != nil {
+			return fmt.Errorf("mount #%d: %w", i, err)
+		}
+		m.MountName = d.Get("name").(string)
+		if seen[m.MountName] {
+			return fmt.Errorf("mount %s is specified more than once", m.MountName)
+		}
+		seen[m.MountName] = true
+	}
+	return nil
+}
+
+// getMountsClusterID returns cluster, where all mounts could be created
+func getMountsClusterID(ctx context.Context, client *common.DatabricksClient, mc MountsConfig) (string, error) {
+	if mc.ClusterID != "" {
+		return getMountingClusterID(ctx, client, mc.ClusterID)
+	}
+	instanceProfile, serviceAccount := "", ""
+	for _, m := range mc.Mounts {
+		if m.S3 != nil && m.S3.InstanceProfile != "" {
+			if instanceProfile != "" && instanceProfile != m.S3.InstanceProfile {
+				return "", fmt.Errorf("mounts require different instance profiles, please specify cluster_id")
+			}
+			instanceProfile = m.S3.InstanceProfile
+		}
+		if m.Gs != nil && m.Gs.ServiceAccount != "" {
+			if serviceAccount != "" && serviceAccount != m.Gs.ServiceAccount {
+				return "", fmt.Errorf("mounts require different service accounts, please specify cluster_id")
+			}
+			serviceAccount = m.Gs.ServiceAccount
+		}
+	}
+	clustersAPI := clusters.NewClustersAPI(ctx, client)
+	switch {
+	case instanceProfile != "" && serviceAccount != "":
+		return "", fmt.Errorf("mounts require both instance profile and service account, please specify cluster_id")
+	case instanceProfile != "":
+		cluster, err := GetOrCreateMountingClusterWithInstanceProfile(clustersAPI, instanceProfile)
+		return cluster.ClusterID, err
+	case serviceAccount != "":
+		cluster, err := GetOrCreateMountingClusterWithGcpServiceAccount(clustersAPI, serviceAccount)
+		return cluster.ClusterID, err
+	default:
+		return getOrCreateMountingCluster(clustersAPI)
+	}
+}
+
+// priorState reads values of the resource, as they were before the update
+type priorState struct {
+	d *schema.ResourceData
+}
+
+func (p priorState) GetOk(key string) (interface{}, bool) {
+	old, _ := p.d.GetChange(key)
+	switch v := old.(type) {
+	case nil:
+		return nil, false
+	case []interface{}:
+		return v, len(v) > 0
+	case map[string]interface{}:
+		return v, len(v) > 0
+	case *schema.Set:
+		return v, v.Len() > 0
+	default:
+		return v, !reflect.ValueOf(v).IsZero()
+	}
+}
+
+// applyMountResults keeps only successfully mounted mount points and records their sources
+func applyMountResults(mc *MountsConfig, results map[string]mountResult) {
+	mounted := []GenericMount{}
+	for _, m := range mc.Mounts {
+		r, ok := results["/mnt/"+m.Name()]
+		if ok && r.Error != "" {
+			delete(mc.Sources, m.Name())
+			continue
+		}
+		if ok {
+			mc.Sources[m.Name()] = r.Source
+		}
+		mounted = append(mounted, m)
+	}
+	mc.Mounts = mounted
+}
+
+// mountsToData saves mounts to state, even if none of them were mounted
+func mountsToData(mc MountsConfig, s map[string]*schema.Schema, d *schema.ResourceData) error {
+	// empty lists and maps are skipped by StructToData
+	if len(mc.Mounts) == 0 {
+		if err := d.Set("mount", []interface{}{}); err != nil {
+			return err
+		}
+	}
+	if len(mc.Sources) == 0 {
+		if err := d.Set("sources", map[string]interface{}{}); err != nil {
+			return err
+		}
+	}
+	return common.StructToData(mc, s, d)
+}
+
+// ResourceDatabricksMounts reconciles many mounts with a single command on the cluster
+func ResourceDatabricksMounts() *schema.Resource {
+	s := common.StructToSchema(MountsConfig{}, func(s map[string]*schema.Schema) map[string]*schema.Schema {
+		ms := s["mount"].Elem.(*schema.Resource).Schema
+		// all mounts share the same cluster
+		delete(ms, "cluster_id")
+		// changed mounts are re-mounted in-place and blocks are matched by position,
+		// so removal of storage-specific block has to be visible in the plan
+		queue := []map[string]*schema.Schema{ms}
+		for len(queue) > 0 {
+			for _, v := range queue[0] {
+				v.ForceNew = false
+				v.DiffSuppressFunc = nil
+				if nested, ok := v.Elem.(*schema.Resource); ok {
+					queue = append(queue, nested.Schema)
+				}
+			}
+			queue = queue[1:]
+		}
+		return s
+	})
+	r := common.Resource{
+		Schema: s,
+		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var mc MountsConfig
+			if err := common.DataToStructPointer(d, s, &mc); err != nil {
+				return err
+			}
+			if err := applyMountDefaults(mc.Mounts, c); err != nil {
+				return err
+			}
+			clusterID, err := getMountsClusterID(ctx, c, mc)
+			if err != nil {
+				return err
+			}
+			mc.ClusterID = clusterID
+			log.Printf("[INFO] Mounting %d mount points on %s", len(mc.Mounts), clusterID)
+			results, err := reconcileMounts(c.CommandExecutor(ctx), c, clusterID, mc.Mounts, nil, nil)
+			forgetMounts(c, clusterID)
+			if err != nil {
+				return err
+			}
+			// failed mount points are kept out of the state and retried during the next apply
+			recordMountFailures(ctx, results)
+			d.SetId(mountsID(mc.Mounts))
+			mc.Sources = map[string]string{}
+			applyMountResults(&mc, results)
+			return mountsToData(mc, s, d)
+		},
+		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var mc MountsConfig
+			if err := common.DataToStructPointer(d, s, &mc); err != nil {
+				return err
+			}
+			imported := d.IsNewResource() && len(mc.Mounts) == 0
+			if imported {
+				// mount points are recovered from the cluster by their names
+				mc.Sources = map[string]string{}
+				for _, name := range strings.Split(d.Id(), ",") {
+					mc.Mounts = append(mc.Mounts, GenericMount{MountName: name})
+				}
+			}
+			if !imported && !d.Get("refresh_via_cluster").(bool) {
+				log.Printf("[DEBUG] Using sources of mounts from state")
+				return nil
+			}
+			clusterID, err := getMountingClusterID(ctx, c, mc.ClusterID)
+			if err != nil {
+				return err
+			}
+			current, err := cachedMounts(c, c.CommandExecutor(ctx), clusterID)
+			if err != nil {
+				return err
+			}
+			mounted := []GenericMount{}
+			for _, m := range mc.Mounts {
+				source, ok := current["/mnt/"+m.Name()]
+				if imported && ok {
+					m.URI = source
+					mc.Sources[m.Name()] = source
+				}
+				if !ok || source != mc.Sources[m.Name()] {
+					// mount point is re-created on the next apply
+					log.Printf("[INFO] /mnt/%s is not mounted from %s", m.Name(), mc.Sources[m.Name()])
+					delete(mc.Sources, m.Name())
+					continue
+				}
+				mounted = append(mounted, m)
+			}
+			mc.Mounts = mounted
+			mc.ClusterID = clusterID
+			return mountsToData(mc, s, d)
+		},
+		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var old, mc MountsConfig
+			if err := common.DiffToStructPointer(priorState{d}, s, &old); err != nil {
+				return err
+			}
+			if err := common.DataToStructPointer(d, s, &mc); err != nil {
+				return err
+			}
+			if err := applyMountDefaults(mc.Mounts, c); err != nil {
+				return err
+			}
+			clusterID, err := getMountsClusterID(ctx, c, mc)
+			if err != nil {
+				return err
+			}
+			mc.ClusterID = clusterID
+			previous := map[string]GenericMount{}
+			for _, m := range old.Mounts {
+				previous[m.Name()] = m
+			}
+			remount := []string{}
+			for _, m := range mc.Mounts {
+				p, ok := previous[m.Name()]
+				if ok && !reflect.DeepEqual(p, m) {
+					remount = append(remount, "/mnt/"+m.Name())
+				}
+				delete(previous, m.Name())
+			}
+			unmount := []string{}
+			for name := range previous {
+				unmount = append(unmount, "/mnt/"+name)
+			}
+			sort.Strings(unmount)
+			results, err := reconcileMounts(c.CommandExecutor(ctx), c, clusterID, mc.Mounts, remount, unmount)
+			forgetMounts(c, clusterID)
+			if err != nil {
+				return err
+			}
+			mc.Sources = map[string]string{}
+			for name, source := range old.Sources {
+				if _, removed := previous[name]; !removed {
+					mc.Sources[name] = source
+				}
+			}
+			applyMountResults(&mc, results)
+			for name, m := range previous {
+				if results["/mnt/"+name].Error != "" {
+					// unmounting is retried during the next apply
+					mc.Mounts = append(mc.Mounts, m)
+					mc.Sources[name] = old.Sources[name]
+				}
+			}
+			recordMountFailures(ctx, results)
+			return mountsToData(mc, s, d)
+		},
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var mc MountsConfig
+			if err := common.DataToStructPointer(d, s, &mc); err != nil {
+				return err
+			}
+			clusterID, err := getMountingClusterID(ctx, c, mc.ClusterID)
+			if err != nil {
+				return err
+			}
+			unmount := []string{}
+			for _, m := range mc.Mounts {
+				unmount = append(unmount, "/mnt/"+m.Name())
+			}
+			log.Printf("[INFO] Unmounting %d mount points on %s", len(unmount), clusterID)
+			results, err := reconcileMounts(c.CommandExecutor(ctx), c, clusterID, nil, nil, unmount)
+			forgetMounts(c, clusterID)
+			if err != nil {
+				return err
+			}
+			return mountErrors(results)
+		},
+	}.ToResource()
+	r.CreateContext = withMountWarnings(r.CreateContext)
+	r.UpdateContext = withMountWarnings(r.UpdateContext)
+	return r
+}
@@ -0,0 +1,440 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/clusters"
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/internal"
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var runningMountsCluster = qa.HTTPFixture{
+	Method:       "GET",
+	ReuseRequest: true,
+	Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+	Response: clusters.ClusterInfo{
+		ClusterID: "abc",
+		State:     clusters.ClusterStateRunning,
+	},
+}
+
+var twoMountsState = map[string]string{
+	"cluster_id":                "abc",
+	"mount.#":                   "2",
+	"mount.0.name":              "a",
+	"mount.0.uri":               "s3a://a",
+	"mount.0.extra_configs.%":   "1",
+	"mount.0.extra_configs.foo": "bar",
+	"mount.1.name":              "b",
+	"mount.1.s3.#":              "1",
+	"mount.1.s3.0.bucket_name":  "b",
+	"sources.%":                 "2",
+	"sources.a":                 "s3a://a",
+	"sources.b":                 "s3a://b",
+}
+
+func TestResourceMountsCreate(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{runningMountsCluster},
+		Resource: ResourceDatabricksMounts(),
+		CommandMock: func(commandStr string) common.CommandResults {
+			trunc := internal.TrimLeadingWhitespace(commandStr)
+			t.Logf("Received command:\n%s", trunc)
+			assert.Contains(t, trunc, `"mount_point":"/mnt/a","source":"s3a://a"`)
+			assert.Contains(t, trunc, `"configs":{"foo":dbutils.secrets.get("scope", "key")}`)
+			assert.Contains(t, trunc, `"mount_point":"/mnt/b","source":"s3a://b"`)
+			assert.Contains(t, trunc, "remount = null")
+			assert.Contains(t, trunc, "unmount = null")
+			return common.CommandResults{
+				ResultType: "text",
+				Data:       `{"/mnt/a": {"source": "s3a://a"}, "/mnt/b": {"source": "s3a://b"}}`,
+			}
+		},
+		State: map[string]interface{}{
+			"cluster_id": "abc",
+			"mount": []interface{}{
+				map[string]interface{}{
+					"name": "a",
+					"uri":  "s3a://a",
+					"extra_configs": map[string]interface{}{
+						"foo": "{{secrets/scope/key}}",
+					},
+				},
+				map[string]interface{}{
+					"s3": []interface{}{map[string]interface{}{
+						"bucket_name": "b",
+					}},
+				},
+			},
+		},
+		Create: true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "a,b", d.Id())
+	assert.Equal(t, "b", d.Get("mount.1.name"))
+	assert.Equal(t, map[string]interface{}{
+		"a": "s3a://a",
+		"b": "s3a://b",
+	}, d.Get("sources"))
+}
+
+func TestResourceMountsCreate_PartialFailure(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{runningMountsCluster},
+		Resource: ResourceDatabricksMounts(),
+		CommandMock: func(commandStr string) common.CommandResults {
+			return common.CommandResults{
+				ResultType: "text",
+				Data:       `{"/mnt/a": {"source": "s3a://a"}, "/mnt/b": {"error": "Access Denied"}}`,
+			}
+		},
+		HCL: `
+		cluster_id = "abc"
+		mount {
+			name = "a"
+			uri = "s3a://a"
+		}
+		mount {
+			name = "b"
+			uri = "s3a://b"
+		}`,
+		Create: true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "a,b", d.Id())
+	assert.Equal(t, 1, d.Get("mount.#"))
+	assert.Equal(t, "a", d.Get("mount.0.name"))
+	assert.Equal(t, map[string]interface{}{
+		"a": "s3a://a",
+	}, d.Get("sources"))
+}
+
+func TestResourceMountsUpdate_RetryFailed(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{runningMountsCluster},
+		Resource: ResourceDatabricksMounts(),
+		CommandMock: func(commandStr string) common.CommandResults {
+			trunc := internal.TrimLeadingWhitespace(commandStr)
+			t.Logf("Received command:\n%s", trunc)
+			// only failed mount point is mounted again
+			assert.Contains(t, trunc, "remount = []")
+			assert.Contains(t, trunc, "unmount = []")
+			assert.Contains(t, trunc, `"mount_point":"/mnt/b","source":"s3a://b"`)
+			return common.CommandResults{
+				ResultType: "text",
+				Data:       `{"/mnt/a": {"source": "s3a://a"}, "/mnt/b": {"source": "s3a://b"}}`,
+			}
+		},
+		InstanceState: map[string]string{
+			"cluster_id":   "abc",
+			"mount.#":      "1",
+			"mount.0.name": "a",
+			"mount.0.uri":  "s3a://a",
+			"sources.%":    "1",
+			"sources.a":    "s3a://a",
+		},
+		HCL: `
+		cluster_id = "abc"
+		mount {
+			name = "a"
+			uri = "s3a://a"
+		}
+		mount {
+			name = "b"
+			uri = "s3a://b"
+		}`,
+		ID:     "a,b",
+		Update: true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, 2, d.Get("mount.#"))
+	assert.Equal(t, map[string]interface{}{
+		"a": "s3a://a",
+		"b": "s3a://b",
+	}, d.Get("sources"))
+}
+
+func TestWithMountWarnings(t *testing.T) {
+	diags := withMountWarnings(func(ctx context.Context,
+		d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		recordMountFailures(ctx, map[string]mountResult{
+			"/mnt/a": {Source: "s3a://a"},
+			"/mnt/c": {Error: "Directory is busy"},
+			"/mnt/b": {Error: "Access Denied"},
+		})
+		return nil
+	})(context.Background(), nil, nil)
+	assert.Equal(t, diag.Diagnostics{
+		{Severity: diag.Warning, Summary: "/mnt/b failed", Detail: "Access Denied"},
+		{Severity: diag.Warning, Summary: "/mnt/c failed", Detail: "Directory is busy"},
+	}, diags)
+}
+
+func TestResourceMountsCreate_CommandError(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{runningMountsCluster},
+		Resource: ResourceDatabricksMounts(),
+		CommandMock: func(commandStr string) common.CommandResults {
+			return common.CommandResults{
+				ResultType: "error",
+				Summary:    "Cluster is terminating",
+			}
+		},
+		HCL: `
+		cluster_id = "abc"
+		mount {
+			name = "a"
+			uri = "s3a://a"
+		}`,
+		Create: true,
+	}.ExpectError(t, "Cluster is terminating")
+}
+
+func TestResourceMountsCreate_Invalid(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceDatabricksMounts(),
+		HCL: `
+		cluster_id = "abc"
+		mount {
+			name = "a"
+			uri = "s3a://a"
+			s3 {
+				bucket_name = "a"
+			}
+		}`,
+		Create: true,
+	}.ExpectError(t, "mount #0: only one of uri, abfs, adl, gs, s3 or wasb could be specified")
+
+	qa.ResourceFixture{
+		Resource: ResourceDatabricksMounts(),
+		HCL: `
+		cluster_id = "abc"
+		mount {
+			uri = "s3a://a"
+			name = "a"
+		}
+		mount {
+			s3 {
+				bucket_name = "a"
+			}
+		}`,
+		Create: true,
+	}.ExpectError(t, "mount a is specified more than once")
+}
+
+func TestResourceMountsRead_FromState(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Resource: ResourceDatabricksMounts(),
+		CommandMock: func(commandStr string) common.CommandResults {
+			t.Fatalf("Unexpected command:\n%s", commandStr)
+			return common.CommandResults{}
+		},
+		InstanceState: twoMountsState,
+		State: map[string]interface{}{
+			"cluster_id": "abc",
+			"mount": []interface{}{
+				map[string]interface{}{
+					"name": "a",
+					"uri":  "s3a://a",
+					"extra_configs": map[string]interface{}{
+						"foo": "bar",
+					},
+				},
+				map[string]interface{}{
+					"s3": []interface{}{map[string]interface{}{
+						"bucket_name": "b",
+					}},
+				},
+			},
+		},
+		ID:   "a,b",
+		Read: true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, 2, d.Get("mount.#"))
+}
+
+func TestResourceMountsRead_RefreshViaCluster(t *testing.T) {
+	state := map[string]string{"refresh_via_cluster": "true"}
+	for k, v := range twoMountsState {
+		state[k] = v
+	}
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{runningMountsCluster},
+		Resource: ResourceDatabricksMounts(),
+		CommandMock: func(commandStr string) common.CommandResults {
+			return common.CommandResults{
+				ResultType: "text",
+				Data:       `[{"mount_point": "/mnt/a", "source": "s3a://a"}, {"mount_point": "/mnt/c", "source": "s3a://c"}]`,
+			}
+		},
+		InstanceState: state,
+		State: map[string]interface{}{
+			"cluster_id":          "abc",
+			"refresh_via_cluster": true,
+			"mount": []interface{}{
+				map[string]interface{}{
+					"name": "a",
+					"uri":  "s3a://a",
+					"extra_configs": map[string]interface{}{
+						"foo": "bar",
+					},
+				},
+				map[string]interface{}{
+					"s3": []interface{}{map[string]interface{}{
+						"bucket_name": "b",
+					}},
+				},
+			},
+		},
+		ID:   "a,b",
+		Read: true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, 1, d.Get("mount.#"))
+	assert.Equal(t, map[string]interface{}{
+		"a": "s3a://a",
+	}, d.Get("sources"))
+}
+
+func TestResourceMountsUpdate(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{runningMountsCluster},
+		Resource: ResourceDatabricksMounts(),
+		CommandMock: func(commandStr string) common.CommandResults {
+			trunc := internal.TrimLeadingWhitespace(commandStr)
+			t.Logf("Received command:\n%s", trunc)
+			assert.Contains(t, trunc, `remount = ["/mnt/a"]`)
+			assert.Contains(t, trunc, `unmount = ["/mnt/b"]`)
+			assert.Contains(t, trunc, `"mount_point":"/mnt/c","source":"s3a://c"`)
+			return common.CommandResults{
+				ResultType: "text",
+				Data: `{"/mnt/a": {"source": "s3a://a"}, "/mnt/b": {},
+					"/mnt/c": {"source": "s3a://c"}}`,
+			}
+		},
+		InstanceState: twoMountsState,
+		State: map[string]interface{}{
+			"cluster_id": "abc",
+			"mount": []interface{}{
+				map[string]interface{}{
+					"name": "a",
+					"uri":  "s3a://a",
+					"extra_configs": map[string]interface{}{
+						"foo": "baz",
+					},
+				},
+				map[string]interface{}{
+					"name": "c",
+					"uri":  "s3a://c",
+				},
+			},
+		},
+		ID:     "a,b",
+		Update: true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, 2, d.Get("mount.#"))
+	assert.Equal(t, map[string]interface{}{
+		"a": "s3a://a",
+		"c": "s3a://c",
+	}, d.Get("sources"))
+}
+
+func TestResourceMountsUpdate_UnmountFailure(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{runningMountsCluster},
+		Resource: ResourceDatabricksMounts(),
+		CommandMock: func(commandStr string) common.CommandResults {
+			return common.CommandResults{
+				ResultType: "text",
+				Data:       `{"/mnt/a": {"source": "s3a://a"}, "/mnt/b": {"error": "Directory is busy"}}`,
+			}
+		},
+		InstanceState: twoMountsState,
+		State: map[string]interface{}{
+			"cluster_id": "abc",
+			"mount": []interface{}{
+				map[string]interface{}{
+					"name": "a",
+					"uri":  "s3a://a",
+					"extra_configs": map[string]interface{}{
+						"foo": "bar",
+					},
+				},
+			},
+		},
+		ID:     "a,b",
+		Update: true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, 2, d.Get("mount.#"))
+	assert.Equal(t, "b", d.Get("mount.1.name"))
+	assert.Equal(t, "s3a://b", d.Get("sources.b"))
+}
+
+func TestResourceMountsDelete(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{runningMountsCluster},
+		Resource: ResourceDatabricksMounts(),
+		CommandMock: func(commandStr string) common.CommandResults {
+			trunc := internal.TrimLeadingWhitespace(commandStr)
+			assert.Contains(t, trunc, "mounts = []")
+			assert.Contains(t, trunc, `unmount = ["/mnt/a","/mnt/b"]`)
+			return common.CommandResults{
+				ResultType: "text",
+				Data:       `{"/mnt/a": {}, "/mnt/b": {}}`,
+			}
+		},
+		InstanceState: twoMountsState,
+		State: map[string]interface{}{
+			"cluster_id": "abc",
+			"mount": []interface{}{
+				map[string]interface{}{
+					"name": "a",
+					"uri":  "s3a://a",
+					"extra_configs": map[string]interface{}{
+						"foo": "bar",
+					},
+				},
+				map[string]interface{}{
+					"s3": []interface{}{map[string]interface{}{
+						"bucket_name": "b",
+					}},
+				},
+			},
+		},
+		ID:     "a,b",
+		Delete: true,
+	}.ApplyNoError(t)
+}
+
+func TestResourceMountsImport(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{runningMountsCluster}, func(ctx context.Context, client *common.DatabricksClient) {
+		client.WithCommandMock(func(commandStr string) common.CommandResults {
+			return common.CommandResults{
+				ResultType: "text",
+				Data:       `[{"mount_point": "/mnt/a", "source": "s3a://a"}, {"mount_point": "/mnt/c", "source": "s3a://c"}]`,
+			}
+		})
+		r := ResourceDatabricksMounts()
+		d := r.Data(nil)
+		d.SetId("a,b")
+		d.Set("cluster_id", "abc")
+		imported, err := r.Importer.StateContext(ctx, d, client)
+		require.NoError(t, err, err)
+		require.Len(t, imported, 1)
+		assert.Equal(t, 1, d.Get("mount.#"))
+		assert.Equal(t, "a", d.Get("mount.0.name"))
+		assert.Equal(t, "s3a://a", d.Get("mount.0.uri"))
+		assert.Equal(t, map[string]interface{}{
+			"a": "s3a://a",
+		}, d.Get("sources"))
+	})
+}
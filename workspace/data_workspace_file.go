@@ -0,0 +1,68 @@
+package workspace
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DataSourceWorkspaceFile reads content of the file in the workspace tree
+func DataSourceWorkspaceFile() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"limit_file_size": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"content_base64": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"md5": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"object_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			notebooksAPI := NewNotebooksAPI(ctx, m)
+			path := d.Get("path").(string)
+			objectStatus, err := readFileStatus(notebooksAPI, path)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			contentBase64, err := notebooksAPI.Export(path, "SOURCE")
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			content, err := base64.StdEncoding.DecodeString(contentBase64)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			// content is kept in state, so it's limited the same way, as for DBFS files
+			if d.Get("limit_file_size").(bool) && len(content) > 4e6 {
+				return diag.Errorf("Size of %s is too large: %d bytes", path, len(content))
+			}
+			d.SetId(path)
+			// nolint
+			d.Set("content_base64", contentBase64)
+			// nolint
+			d.Set("md5", fmt.Sprintf("%x", md5.Sum(content)))
+			// nolint
+			d.Set("object_id", objectStatus.ObjectID)
+			return nil
+		},
+	}
+}
@@ -17,6 +17,7 @@ import (
 const (
 	Notebook  string = "NOTEBOOK"
 	Directory string = "DIRECTORY"
+	File      string = "FILE"
 	Scala     string = "SCALA"
 	Python    string = "PYTHON"
 	SQL       string = "SQL"
@@ -0,0 +1,132 @@
+package workspace
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"fmt"
+	"path/filepath"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// fileChecksum returns MD5 checksum of base64-encoded content, the same as ReadContent
+func fileChecksum(contentBase64 string) (string, error) {
+	content, err := base64.StdEncoding.DecodeString(contentBase64)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", md5.Sum(content)), nil
+}
+
+// readFileStatus returns status of the workspace object, failing if it's not a file
+func readFileStatus(notebooksAPI NotebooksAPI, path string) (ObjectStatus, error) {
+	objectStatus, err := notebooksAPI.Read(path)
+	if err != nil {
+		return objectStatus, err
+	}
+	if objectStatus.ObjectType != File {
+		return objectStatus, fmt.Errorf("%s is %s, not a file", path, objectStatus.ObjectType)
+	}
+	return objectStatus, nil
+}
+
+// ResourceWorkspaceFile manages files in the workspace tree, that are not notebooks
+func ResourceWorkspaceFile() *schema.Resource {
+	s := FileContentSchema(map[string]*schema.Schema{
+		"url": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"object_id": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"verify_checksum": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+	})
+	importFile := func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+		content, err := ReadContent(d)
+		if err != nil {
+			return err
+		}
+		// AUTO format imports content as is, unless it looks like a notebook
+		notebooksAPI := NewNotebooksAPI(ctx, c)
+		path := d.Get("path").(string)
+		err = notebooksAPI.Create(ImportPath{
+			Content:   base64.StdEncoding.EncodeToString(content),
+			Format:    "AUTO",
+			Path:      path,
+			Overwrite: true,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = readFileStatus(notebooksAPI, path)
+		if err != nil {
+			// don't leave behind notebooks, that won't be managed by this resource
+			if deleteErr := notebooksAPI.Delete(path, false); deleteErr != nil {
+				return fmt.Errorf("%w, and cannot delete it: %s", err, deleteErr)
+			}
+			return err
+		}
+		return nil
+	}
+	return common.Resource{
+		Schema: s,
+		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			path := d.Get("path").(string)
+			parent := filepath.ToSlash(filepath.Dir(path))
+			if parent != "/" {
+				err := NewNotebooksAPI(ctx, c).Mkdirs(parent)
+				if err != nil {
+					return err
+				}
+			}
+			if err := importFile(ctx, d, c); err != nil {
+				return err
+			}
+			d.SetId(path)
+			return nil
+		},
+		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			notebooksAPI := NewNotebooksAPI(ctx, c)
+			objectStatus, err := readFileStatus(notebooksAPI, d.Id())
+			if err != nil {
+				return err
+			}
+			d.Set("path", d.Id())
+			d.Set("object_id", objectStatus.ObjectID)
+			d.Set("url", c.FormatURL("#workspace", d.Id()))
+			if !d.Get("verify_checksum").(bool) {
+				return nil
+			}
+			// remote checksum triggers re-import of files, that were changed outside of terraform
+			contentBase64, err := notebooksAPI.Export(d.Id(), "SOURCE")
+			if err != nil {
+				return err
+			}
+			checksum, err := fileChecksum(contentBase64)
+			if err != nil {
+				return err
+			}
+			d.Set("md5", checksum)
+			return nil
+		},
+		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			if !d.HasChanges("md5", "content_base64", "source") {
+				// checksum verification could be toggled without importing the file again
+				return nil
+			}
+			return importFile(ctx, d, c)
+		},
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			return NewNotebooksAPI(ctx, c).Delete(d.Id(), false)
+		},
+	}.ToResource()
+}
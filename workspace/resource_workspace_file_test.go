@@ -0,0 +1,339 @@
+package workspace
+
+import (
+	"encoding/base64"
+	"net/http"
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func workspaceFileReadFixtures(path, content string) []qa.HTTPFixture {
+	return []qa.HTTPFixture{
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/workspace/get-status?path=" + path,
+			Response: ObjectStatus{
+				ObjectID:   4567,
+				ObjectType: File,
+				Path:       "/foo/requirements.txt",
+			},
+			ReuseRequest: true,
+		},
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/workspace/export?format=SOURCE&path=" + path,
+			Response: ExportPath{
+				Content: content,
+			},
+		},
+	}
+}
+
+func TestResourceWorkspaceFileCreate(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: append([]qa.HTTPFixture{
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/workspace/mkdirs",
+				ExpectedRequest: map[string]string{
+					"path": "/foo",
+				},
+			},
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/workspace/import",
+				ExpectedRequest: ImportPath{
+					Content:   "YWJjCg==",
+					Path:      "/foo/requirements.txt",
+					Overwrite: true,
+					Format:    "AUTO",
+				},
+			},
+		}, workspaceFileReadFixtures("%2Ffoo%2Frequirements.txt", "YWJjCg==")...),
+		Resource: ResourceWorkspaceFile(),
+		State: map[string]interface{}{
+			"content_base64": "YWJjCg==",
+			"path":           "/foo/requirements.txt",
+		},
+		Create: true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "/foo/requirements.txt", d.Id())
+	assert.Equal(t, "0bee89b07a248e27c83fc3d5951213c1", d.Get("md5"))
+	assert.Equal(t, 4567, d.Get("object_id"))
+}
+
+func TestResourceWorkspaceFileCreate_Error(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/workspace/import",
+				Response: common.APIErrorBody{
+					ErrorCode: "INVALID_REQUEST",
+					Message:   "Internal error happened",
+				},
+				Status: 400,
+			},
+		},
+		Resource: ResourceWorkspaceFile(),
+		State: map[string]interface{}{
+			"content_base64": "YWJjCg==",
+			"path":           "/requirements.txt",
+		},
+		Create: true,
+	}.ExpectError(t, "Internal error happened")
+}
+
+func TestResourceWorkspaceFileCreate_Notebook(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/workspace/import",
+				ExpectedRequest: ImportPath{
+					Content:   "IyBEYXRhYnJpY2tzIG5vdGVib29rIHNvdXJjZQo=",
+					Path:      "/foo.py",
+					Overwrite: true,
+					Format:    "AUTO",
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/workspace/get-status?path=%2Ffoo.py",
+				Response: ObjectStatus{
+					ObjectID:   4567,
+					ObjectType: Notebook,
+					Path:       "/foo.py",
+				},
+			},
+			{
+				Method:          http.MethodPost,
+				Resource:        "/api/2.0/workspace/delete",
+				ExpectedRequest: DeletePath{Path: "/foo.py"},
+			},
+		},
+		Resource: ResourceWorkspaceFile(),
+		State: map[string]interface{}{
+			"content_base64": "IyBEYXRhYnJpY2tzIG5vdGVib29rIHNvdXJjZQo=",
+			"path":           "/foo.py",
+		},
+		Create: true,
+	}.ExpectError(t, "/foo.py is NOTEBOOK, not a file")
+}
+
+func TestResourceWorkspaceFileRead_Notebook(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/workspace/get-status?path=%2Ffoo.py",
+				Response: ObjectStatus{
+					ObjectID:   4567,
+					ObjectType: Notebook,
+					Path:       "/foo.py",
+				},
+			},
+		},
+		Resource: ResourceWorkspaceFile(),
+		Read:     true,
+		ID:       "/foo.py",
+	}.ExpectError(t, "/foo.py is NOTEBOOK, not a file")
+}
+
+func TestResourceWorkspaceFileRead_ChangedRemotely(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: workspaceFileReadFixtures("%2Ffoo%2Frequirements.txt", "ZGVmCg=="),
+		Resource: ResourceWorkspaceFile(),
+		InstanceState: map[string]string{
+			"path":            "/foo/requirements.txt",
+			"md5":             "0bee89b07a248e27c83fc3d5951213c1",
+			"verify_checksum": "true",
+		},
+		State: map[string]interface{}{
+			"content_base64":  "YWJjCg==",
+			"path":            "/foo/requirements.txt",
+			"verify_checksum": true,
+		},
+		ID:   "/foo/requirements.txt",
+		Read: true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	// md5 of remote "def\n" differs from the state, so that the file is imported again
+	assert.Equal(t, "614dd0e977becb4c6f7fa99e64549b12", d.Get("md5"))
+}
+
+func TestResourceWorkspaceFileRead_NoChecksumVerification(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/workspace/get-status?path=%2Ffoo%2Frequirements.txt",
+				Response: ObjectStatus{
+					ObjectID:   4567,
+					ObjectType: File,
+					Path:       "/foo/requirements.txt",
+				},
+			},
+		},
+		Resource: ResourceWorkspaceFile(),
+		InstanceState: map[string]string{
+			"path": "/foo/requirements.txt",
+			"md5":  "0bee89b07a248e27c83fc3d5951213c1",
+		},
+		State: map[string]interface{}{
+			"content_base64": "YWJjCg==",
+			"path":           "/foo/requirements.txt",
+		},
+		ID:   "/foo/requirements.txt",
+		Read: true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	// content is not exported, so md5 stays as it was
+	assert.Equal(t, "0bee89b07a248e27c83fc3d5951213c1", d.Get("md5"))
+	assert.Equal(t, 4567, d.Get("object_id"))
+}
+
+func TestResourceWorkspaceFileRead_NotFound(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/workspace/get-status?path=%2Ffoo%2Fbar.yml",
+				Response: common.APIErrorBody{
+					ErrorCode: "NOT_FOUND",
+					Message:   "Item not found",
+				},
+				Status: 404,
+			},
+		},
+		Resource: ResourceWorkspaceFile(),
+		Read:     true,
+		Removed:  true,
+		ID:       "/foo/bar.yml",
+	}.ApplyNoError(t)
+}
+
+func TestResourceWorkspaceFileUpdate(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: append([]qa.HTTPFixture{
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/workspace/import",
+				ExpectedRequest: ImportPath{
+					Content:   "YWJjCg==",
+					Path:      "/foo/requirements.txt",
+					Overwrite: true,
+					Format:    "AUTO",
+				},
+			},
+		}, workspaceFileReadFixtures("%2Ffoo%2Frequirements.txt", "YWJjCg==")...),
+		Resource: ResourceWorkspaceFile(),
+		InstanceState: map[string]string{
+			"path":           "/foo/requirements.txt",
+			"content_base64": "ZGVmCg==",
+			"md5":            "different",
+		},
+		State: map[string]interface{}{
+			"content_base64": "YWJjCg==",
+			"path":           "/foo/requirements.txt",
+		},
+		ID:     "/foo/requirements.txt",
+		Update: true,
+	}.ApplyNoError(t)
+}
+
+func TestResourceWorkspaceFileUpdate_VerifyChecksumOnly(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: workspaceFileReadFixtures("%2Ffoo%2Frequirements.txt", "YWJjCg=="),
+		Resource: ResourceWorkspaceFile(),
+		InstanceState: map[string]string{
+			"path":           "/foo/requirements.txt",
+			"content_base64": "YWJjCg==",
+			"md5":            "0bee89b07a248e27c83fc3d5951213c1",
+		},
+		State: map[string]interface{}{
+			"content_base64":  "YWJjCg==",
+			"path":            "/foo/requirements.txt",
+			"verify_checksum": true,
+		},
+		ID:     "/foo/requirements.txt",
+		Update: true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "0bee89b07a248e27c83fc3d5951213c1", d.Get("md5"))
+}
+
+func TestResourceWorkspaceFileDelete(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:          http.MethodPost,
+				Resource:        "/api/2.0/workspace/delete",
+				ExpectedRequest: DeletePath{Path: "/foo/requirements.txt"},
+			},
+		},
+		Resource: ResourceWorkspaceFile(),
+		Delete:   true,
+		ID:       "/foo/requirements.txt",
+	}.ApplyNoError(t)
+}
+
+func TestDataSourceWorkspaceFile(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures:    workspaceFileReadFixtures("%2Ffoo%2Frequirements.txt", "YWJjCg=="),
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceWorkspaceFile(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"path": "/foo/requirements.txt",
+		},
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "/foo/requirements.txt", d.Id())
+	assert.Equal(t, "YWJjCg==", d.Get("content_base64"))
+	assert.Equal(t, "0bee89b07a248e27c83fc3d5951213c1", d.Get("md5"))
+	assert.Equal(t, 4567, d.Get("object_id"))
+}
+
+func TestDataSourceWorkspaceFile_Notebook(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/workspace/get-status?path=%2Ffoo%2Fbar",
+				Response: ObjectStatus{
+					ObjectID:   1,
+					ObjectType: Notebook,
+					Path:       "/foo/bar",
+				},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceWorkspaceFile(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"path": "/foo/bar",
+		},
+	}.ExpectError(t, "/foo/bar is NOTEBOOK, not a file")
+}
+
+func TestDataSourceWorkspaceFile_TooLarge(t *testing.T) {
+	content := base64.StdEncoding.EncodeToString(make([]byte, 4000001))
+	qa.ResourceFixture{
+		Fixtures:    workspaceFileReadFixtures("%2Ffoo%2Fbig.csv", content),
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceWorkspaceFile(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"path": "/foo/big.csv",
+		},
+	}.ExpectError(t, "Size of /foo/big.csv is too large: 4000001 bytes")
+}